@@ -1,15 +1,21 @@
 package app
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pterm/pterm"
 	"github.com/urfave/cli/v2"
 
@@ -26,6 +32,29 @@ const (
 	envFocusNoColor   = "FOCUS_NO_COLOR"
 )
 
+const (
+	statsFormatBrowser  = "browser"
+	statsFormatTerminal = "terminal"
+	statsFormatJSON     = "json"
+)
+
+// formatSize returns a human readable representation of a size in bytes.
+func formatSize(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // firstNonEmptyString returns its first non-empty argument, or "" if all
 // arguments are empty.
 func firstNonEmptyString(ss ...string) string {
@@ -79,20 +108,598 @@ func checkForUpdates(app *cli.App) {
 	}
 }
 
-func sessionHelper(ctx *cli.Context) ([]*models.Session, store.DB, error) {
+// openStore connects to the storage backend set in the config file.
+func openStore() (store.DB, error) {
+	return store.Open(config.DBBackend(), config.DBFilePath())
+}
+
+// openStoreReadOnly connects to the storage backend set in the config file for
+// commands that only read from it, so that they work while a timer is active.
+func openStoreReadOnly() (store.DB, error) {
+	return store.OpenReadOnly(config.DBBackend(), config.DBFilePath())
+}
+
+// openBolt connects to the bolt database for commands that don't support
+// other storage backends.
+func openBolt() (*store.Client, error) {
+	err := requireBolt()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.NewClient(config.DBFilePath())
+}
+
+// requireBolt returns an error if the configured storage backend is not bolt.
+func requireBolt() error {
+	if backend := config.DBBackend(); backend != store.BackendBolt {
+		return fmt.Errorf("%w (current backend: %s)", errBoltBackendOnly, backend)
+	}
+
+	return nil
+}
+
+// sessionHelper retrieves the sessions that match the specified filters. The
+// database is only opened for reading while the sessions are retrieved so that
+// an active timer can keep saving its sessions while the command waits for
+// confirmation.
+func sessionHelper(ctx *cli.Context) ([]*models.Session, error) {
 	conf := config.Filter(ctx)
 
-	db, err := store.NewClient(config.DBFilePath())
+	db, err := openStoreReadOnly()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	sessions, err := db.GetSessions(conf.StartTime, conf.EndTime, conf.Tags)
+	defer db.Close()
+
+	return db.GetSessions(conf.StartTime, conf.EndTime, conf.Tags)
+}
+
+// updateSessions opens the database for writing, and saves the latest version
+// of each of the provided sessions after applying fn to it. The sessions are
+// retrieved again since an active timer may have saved them after they were
+// displayed, and sessions that no longer exist are skipped.
+func updateSessions(
+	open func() (store.DB, error),
+	sessions []*models.Session,
+	fn func(sess *models.Session),
+) error {
+	db, err := open()
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+
+	defer db.Close()
+
+	m := make(map[time.Time]*models.Session)
+
+	for _, v := range sessions {
+		sess, err := db.GetSession(v.StartTime)
+		if errors.Is(err, store.ErrSessionNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		fn(sess)
+
+		m[sess.StartTime] = sess
+	}
+
+	return db.UpdateSessions(m)
+}
+
+// excludeBreaks removes break sessions from the provided sessions unless the
+// --breaks flag is set.
+func excludeBreaks(
+	ctx *cli.Context,
+	sessions []*models.Session,
+) []*models.Session {
+	if ctx.Bool("breaks") {
+		return sessions
+	}
+
+	return slices.DeleteFunc(sessions, (*models.Session).IsBreak)
+}
+
+// confirm prints the specified warning and waits for the user to press ENTER
+// before proceeding. It returns errCancelled if the input ends first.
+func confirm(warning string) error {
+	fmt.Fprint(config.Stdout, pterm.Warning.Sprint(warning))
+
+	_, err := bufio.NewReader(config.Stdin).ReadString('\n')
+	if errors.Is(err, io.EOF) {
+		return errCancelled
+	}
+
+	return err
+}
+
+// listAction handles the list command which prints the sessions that match
+// the specified filters.
+func listAction(ctx *cli.Context) error {
+	sessions, err := sessionHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	return listSessions(excludeBreaks(ctx, sessions), ctx.Bool("json"))
+}
+
+// requireTimeRange ensures that a time range is specified for commands that
+// change sessions, so that they never act on the default reporting period by
+// accident.
+func requireTimeRange(ctx *cli.Context) error {
+	if !ctx.IsSet("period") && !ctx.IsSet("start") && !ctx.IsSet("end") {
+		return errNoTimeRange
+	}
+
+	return nil
+}
+
+// deleteAction handles the delete command which permanently removes the
+// sessions that match the specified filters.
+func deleteAction(ctx *cli.Context) error {
+	err := requireTimeRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := sessionHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	return delSessions(openStore, excludeBreaks(ctx, sessions), ctx.Bool("yes"))
+}
+
+// exportAction handles the export command which writes the sessions that
+// match the specified filters to the standard output.
+func exportAction(ctx *cli.Context) error {
+	sessions, err := sessionHelper(ctx)
+	if err != nil {
+		return err
 	}
 
-	return sessions, db, nil
+	return exportSessions(
+		os.Stdout,
+		excludeBreaks(ctx, sessions),
+		ctx.String("format"),
+	)
+}
+
+// importAction handles the import command which adds the sessions in the
+// specified file to the database.
+func importAction(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errNoImportFile
+	}
+
+	sessions, err := readImportFile(ctx.Args().First(), ctx.String("format"))
+	if err != nil {
+		return err
+	}
+
+	return importSessions(
+		openStoreReadOnly,
+		openStore,
+		sessions,
+		ctx.Bool("dry-run"),
+		ctx.Bool("yes"),
+	)
+}
+
+// reindexAction handles the db reindex command which rebuilds the session
+// indexes from scratch.
+func reindexAction(_ *cli.Context) error {
+	db, err := openBolt()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	err = db.RebuildIndexes()
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Println("Session indexes rebuilt successfully")
+
+	return nil
+}
+
+// convertAction handles the db migrate command with the --to option which
+// copies all the sessions to a different storage backend.
+func convertAction(ctx *cli.Context) error {
+	from, to := config.DBBackend(), ctx.String("to")
+	if from == to {
+		return fmt.Errorf("%w: %s", errSameBackend, to)
+	}
+
+	src, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	dst, err := store.Open(to, config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer dst.Close()
+
+	n, err := store.Convert(dst, src, ctx.Bool("dry-run"))
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("dry-run") {
+		pterm.Info.Printfln("%d session(s) would be copied to the %s backend", n, to)
+		return nil
+	}
+
+	pterm.Success.Printfln(
+		"%d session(s) copied to %s. Set `db_backend: %s` in your config file to use it",
+		n,
+		store.Path(to, config.DBFilePath()),
+		to,
+	)
+
+	return nil
+}
+
+// migrateAction handles the db migrate command which upgrades the database to
+// the latest schema version, or converts it to another storage backend.
+func migrateAction(ctx *cli.Context) error {
+	if ctx.String("to") != "" {
+		return convertAction(ctx)
+	}
+
+	err := requireBolt()
+	if err != nil {
+		return err
+	}
+
+	dryRun := ctx.Bool("dry-run")
+
+	applied, backup, err := store.Migrate(config.DBFilePath(), dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		pterm.Info.Println("The database is already up to date")
+		return nil
+	}
+
+	for _, m := range applied {
+		fmt.Printf("%d: %s\n", m.Version, m.Description)
+	}
+
+	if dryRun {
+		pterm.Info.Printfln("%d migration(s) would be applied", len(applied))
+		return nil
+	}
+
+	pterm.Success.Printfln(
+		"%d migration(s) applied. The previous database was backed up to %s",
+		len(applied),
+		backup,
+	)
+
+	return nil
+}
+
+// backupAction handles the db backup command which saves a snapshot of the
+// database to the specified file or the backups directory.
+func backupAction(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		path = store.BackupPath(config.DBFilePath(), config.BackupDirPath())
+	}
+
+	db, err := openBolt()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	n, err := db.BackupFile(path)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("Database backed up to %s (%s)", path, formatSize(n))
+
+	return nil
+}
+
+// restoreAction handles the db restore command which replaces the database
+// with a backup after checking its integrity.
+func restoreAction(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errNoBackupFile
+	}
+
+	err := requireBolt()
+	if err != nil {
+		return err
+	}
+
+	// the database file is replaced, so it must not be in use by a timer
+	unlock, err := store.LockInstance(config.LockFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if !ctx.Bool("yes") {
+		err = confirm(fmt.Sprintf(
+			"The database will be replaced with %s. Press ENTER to proceed",
+			ctx.Args().First(),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	previous, err := store.Restore(
+		config.DBFilePath(),
+		ctx.Args().First(),
+		config.BackupDirPath(),
+	)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln(
+		"Database restored. The previous database was saved to %s",
+		previous,
+	)
+
+	return nil
+}
+
+// compactAction handles the db compact command which reclaims unused space in
+// the database file.
+func compactAction(_ *cli.Context) error {
+	err := requireBolt()
+	if err != nil {
+		return err
+	}
+
+	// the database file is replaced, so it must not be in use by a timer
+	unlock, err := store.LockInstance(config.LockFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	before, after, err := store.Compact(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	if before == after {
+		pterm.Info.Println("There is no unused space to reclaim")
+		return nil
+	}
+
+	pterm.Success.Printfln(
+		"Database compacted from %s to %s",
+		formatSize(before),
+		formatSize(after),
+	)
+
+	return nil
+}
+
+// editTagsAction handles the edit-tag command which replaces the tags of the
+// sessions that match the specified filters with the provided arguments.
+func editTagsAction(ctx *cli.Context) error {
+	err := requireTimeRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	var tags []string
+
+	for _, arg := range ctx.Args().Slice() {
+		for _, tag := range strings.Split(arg, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	if len(tags) == 0 {
+		return errNoTags
+	}
+
+	sessions, err := sessionHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	return editTags(openStore, excludeBreaks(ctx, sessions), tags, ctx.Bool("yes"))
+}
+
+// noteAction handles the note command which adds a note or intent to the
+// specified sessions, or the most recent session if no time range is
+// specified.
+func noteAction(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errNoNote
+	}
+
+	note := strings.TrimSpace(strings.Join(ctx.Args().Slice(), " "))
+
+	sessions, err := sessionHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	sessions = excludeBreaks(ctx, sessions)
+
+	// only the most recent session is updated unless a time range is
+	// specified so that a note isn't added to every session by accident
+	// (sessions are sorted by their start time)
+	last := ctx.Bool("last") ||
+		!ctx.IsSet("period") && !ctx.IsSet("start") && !ctx.IsSet("end")
+
+	if last && len(sessions) > 1 {
+		sessions = sessions[len(sessions)-1:]
+	}
+
+	return editNotes(
+		openStore,
+		sessions,
+		note,
+		ctx.Bool("intent"),
+		ctx.Bool("yes"),
+	)
+}
+
+// taskAddAction handles the task add command which creates a new task.
+func taskAddAction(ctx *cli.Context) error {
+	name := strings.TrimSpace(strings.Join(ctx.Args().Slice(), " "))
+	if name == "" {
+		return errNoTaskName
+	}
+
+	task := &models.Task{
+		Name:      name,
+		Estimate:  int(ctx.Uint("estimate")),
+		CreatedAt: time.Now(),
+	}
+
+	for _, tag := range strings.Split(ctx.String("tag"), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	err = db.UpdateTask(task)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("Added task %s", timer.TaskLabel(task))
+
+	return nil
+}
+
+// taskListAction handles the task list command which prints the saved tasks
+// along with the work recorded against each one.
+func taskListAction(ctx *cli.Context) error {
+	db, err := openStoreReadOnly()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	tasks, err := db.GetTasks()
+	if err != nil {
+		return err
+	}
+
+	sessions, err := db.GetSessions(time.Time{}, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+
+	return listTasks(
+		tasks,
+		taskTotals(sessions),
+		ctx.Bool("all"),
+		ctx.Bool("json"),
+	)
+}
+
+// taskDoneAction handles the task done command which marks the specified
+// tasks as done.
+func taskDoneAction(ctx *cli.Context) error {
+	return taskStatusHelper(ctx, models.TaskDone)
+}
+
+// taskArchiveAction handles the task archive command which hides the specified
+// tasks from the task list and picker.
+func taskArchiveAction(ctx *cli.Context) error {
+	return taskStatusHelper(ctx, models.TaskArchived)
+}
+
+// taskStatusHelper sets the status of the tasks specified in the command-line
+// arguments.
+func taskStatusHelper(ctx *cli.Context, status models.TaskStatus) error {
+	ids, err := parseTaskIDs(ctx.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return setTaskStatus(db, ids, status)
+}
+
+// estimateAction handles the estimate command which sets the estimate of the
+// specified tags, or lists the current estimates if no arguments are provided.
+func estimateAction(ctx *cli.Context) error {
+	args := ctx.Args().Slice()
+
+	if len(args) != 0 && len(args) != 2 {
+		return errInvalidEstimate
+	}
+
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if len(args) == 0 {
+		return listTagEstimates(db)
+	}
+
+	estimate, err := strconv.Atoi(args[1])
+	if err != nil || estimate < 0 {
+		return errInvalidEstimate
+	}
+
+	var tags []string
+
+	for _, tag := range strings.Split(args[0], ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return setTagEstimates(db, tags, estimate)
 }
 
 // editConfigAction handles the edit-config command which opens the focus config
@@ -128,24 +735,47 @@ func editConfigAction(ctx *cli.Context) error {
 
 // statsAction computes the stats for the specified time period.
 func statsAction(ctx *cli.Context) error {
-	sessions, db, err := sessionHelper(ctx)
+	opts := config.Filter(ctx)
+
+	db, err := openStoreReadOnly()
 	if err != nil {
 		return err
 	}
 
-	opts := config.Filter(ctx)
+	defer db.Close()
+
+	sessions, err := db.GetSessions(opts.StartTime, opts.EndTime, opts.Tags)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := db.GetTasks()
+	if err != nil {
+		return err
+	}
 
-	//nolint:govet // unkeyed fields are fine here
 	s := &stats.Stats{
 		Opts: stats.Opts{
-			*opts,
+			FilterConfig: *opts,
 		},
-		DB: db,
+		DB:    db,
+		Tasks: tasks,
+	}
+
+	err = s.LoadEstimates()
+	if err != nil {
+		return err
 	}
 
 	s.Compute(sessions)
 
+	format := ctx.String("format")
 	if ctx.Bool("json") {
+		format = statsFormatJSON
+	}
+
+	switch format {
+	case statsFormatJSON:
 		b, err := s.ToJSON()
 		if err != nil {
 			return err
@@ -154,17 +784,195 @@ func statsAction(ctx *cli.Context) error {
 		fmt.Println(string(b))
 
 		return nil
-	}
+	case statsFormatTerminal:
+		return s.Report(os.Stdout)
+	case statsFormatBrowser:
+		// the server runs until it is stopped, so the database is closed
+		// now and only opened while handling each request to avoid
+		// blocking the timer
+		err = db.Close()
+		if err != nil {
+			return err
+		}
+
+		s.DB = store.NewTransient(db)
 
-	return s.Server(ctx.Uint("port"))
+		return s.Server(ctx.Uint("port"))
+	default:
+		return errInvalidStatsFormat
+	}
 }
 
 // statusAction handles the status command and prints the status of the currently
-// running timer.
-func statusAction(_ *cli.Context) error {
-	t := &timer.Timer{}
+// running timer, or streams it every second with --watch.
+func statusAction(ctx *cli.Context) error {
+	render, err := statusFormatter(ctx.String("format"))
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool("watch") {
+		return printStatus(os.Stdout, render, false)
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		err = printStatus(os.Stdout, render, true)
+		if err != nil {
+			return err
+		}
 
-	return t.ReportStatus()
+		<-ticker.C
+	}
+}
+
+// ctlAction handles the ctl subcommands which send the command of the same
+// name to the active timer.
+func ctlAction(ctx *cli.Context) error {
+	status, err := timer.Control(config.SocketFilePath(), timer.ControlRequest{
+		Command: ctx.Command.Name,
+		Args:    ctx.Args().Slice(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctx.Command.Name != timer.CtlStatus {
+		return nil
+	}
+
+	b, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+// openTimerDB opens the database for a timer and takes the automatic backup
+// for the day if the bolt backend is used. A failed backup is logged but
+// doesn't prevent the timer from starting. The database is only held open
+// during each operation so that other commands can use it while the timer is
+// active, and the returned function releases the instance lock that stops
+// other timers from starting in the meantime.
+func openTimerDB(cfg *config.TimerConfig) (store.DB, func() error, error) {
+	unlock, err := store.LockInstance(config.LockFilePath())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := store.Open(config.DBBackend(), cfg.PathToDB)
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+
+	if c, ok := db.(*store.Client); ok {
+		err = c.AutoBackup(config.BackupDirPath(), cfg.BackupCount)
+		if err != nil {
+			slog.Error("automatic backup failed", slog.Any("error", err))
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+
+	return store.NewTransient(db), unlock, nil
+}
+
+// runTimer runs the timer until it exits, and accepts commands from
+// `focus ctl` in the meantime. The timer still runs if the control socket
+// cannot be created, except in headless mode where nothing else can control
+// it.
+func runTimer(t *timer.Timer, headless bool) error {
+	p := t.NewProgram(headless)
+
+	ln, err := t.Listen(p, config.SocketFilePath())
+	if err != nil {
+		// a headless timer cannot be controlled without the socket
+		if headless {
+			return err
+		}
+
+		slog.Error("control socket unavailable", slog.Any("error", err))
+	} else {
+		defer ln.Close()
+	}
+
+	if headless {
+		stop := timer.StopOnSignal(p)
+		defer stop()
+
+		slog.Info(
+			"timer started in headless mode",
+			slog.String("socket", config.SocketFilePath()),
+		)
+	}
+
+	_, err = p.Run()
+	if err != nil {
+		return err
+	}
+
+	err = t.SaveErr()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errSessionNotSaved, err)
+	}
+
+	// the timer exits on its own when the session limit or daily goal is
+	// reached
+	if summary := t.Summary(); summary != "" && !headless {
+		pterm.Success.Println(summary)
+	}
+
+	return nil
+}
+
+// resumeAction handles the resume command which continues an interrupted
+// work session selected by its number or through an interactive prompt.
+func resumeAction(ctx *cli.Context) error {
+	var index int
+
+	if ctx.Args().Present() {
+		var err error
+
+		index, err = strconv.Atoi(ctx.Args().First())
+		if err != nil || index < 1 {
+			return errInvalidIndex
+		}
+	} else if ctx.Bool("headless") {
+		// the session cannot be selected interactively without a terminal,
+		// so the most recent one is resumed
+		index = 1
+	}
+
+	cfg := config.Timer(ctx)
+
+	dbClient, unlock, err := openTimerDB(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	t, err := timer.New(dbClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	err = t.Resume(index, ctx.Bool("reset"))
+	if err != nil {
+		return err
+	}
+
+	return runTimer(t, ctx.Bool("headless"))
 }
 
 // defaultAction starts a timer or adds a completed session depending on the
@@ -172,21 +980,36 @@ func statusAction(_ *cli.Context) error {
 func defaultAction(ctx *cli.Context) error {
 	cfg := config.Timer(ctx)
 
-	dbClient, err := store.NewClient(cfg.PathToDB)
+	dbClient, unlock, err := openTimerDB(cfg)
 	if err != nil {
 		return err
 	}
 
+	defer unlock()
+
+	if ctx.IsSet("estimate") {
+		err = setTagEstimates(dbClient, cfg.Tags, int(ctx.Uint("estimate")))
+		if err != nil {
+			return err
+		}
+	}
+
 	t, err := timer.New(dbClient, cfg)
 	if err != nil {
 		return err
 	}
 
-	p := tea.NewProgram(t)
+	// the task picker needs a terminal
+	task, err := sessionTask(dbClient, ctx.String("task"), !ctx.Bool("headless"))
+	if err != nil {
+		return err
+	}
 
-	_, err = p.Run()
+	if task != nil {
+		t.SetTask(task)
+	}
 
-	return err
+	return runTimer(t, ctx.Bool("headless"))
 }
 
 func beforeAction(ctx *cli.Context) error {
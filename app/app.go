@@ -5,6 +5,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/timer"
 )
 
 // disableStyling disables all styling provided by pterm.
@@ -37,28 +38,266 @@ func Get() *cli.App {
 		Version:              config.Version,
 		EnableBashCompletion: true,
 		Commands: []*cli.Command{
+			{
+				Name:  "ctl",
+				Usage: "Control the active timer",
+				Subcommands: []*cli.Command{
+					{
+						Name:      timer.CtlAddTag,
+						Usage:     "Add tags to the current and following sessions",
+						UsageText: "focus ctl add-tag <tag>[,<tag>...]",
+						Action:    ctlAction,
+					},
+					{
+						Name:   timer.CtlPause,
+						Usage:  "Pause the current work session",
+						Action: ctlAction,
+					},
+					{
+						Name:   timer.CtlResume,
+						Usage:  "Resume a paused session or start the next session",
+						Action: ctlAction,
+					},
+					{
+						Name:      timer.CtlSetSound,
+						Usage:     "Change the ambient sound or turn it off",
+						UsageText: "focus ctl set-sound <sound|off>",
+						Action:    ctlAction,
+					},
+					{
+						Name:   timer.CtlSkip,
+						Usage:  "Skip the current break session",
+						Action: ctlAction,
+					},
+					{
+						Name:   timer.CtlStatus,
+						Usage:  "Print the status of the timer in JSON format",
+						Action: ctlAction,
+					},
+					{
+						Name:   timer.CtlStop,
+						Usage:  "Save the current session and exit the timer",
+						Action: ctlAction,
+					},
+				},
+			},
+			{
+				Name:  "db",
+				Usage: "Manage the Focus database",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "backup",
+						Usage:     "Save a snapshot of the database",
+						UsageText: "focus db backup [<file>]",
+						Action:    backupAction,
+					},
+					{
+						Name:   "compact",
+						Usage:  "Reclaim unused space in the database file",
+						Action: compactAction,
+					},
+					{
+						Name:   "migrate",
+						Usage:  "Upgrade the database or convert it to another storage backend",
+						Action: migrateAction,
+						Flags: []cli.Flag{
+							migrateToFlag,
+							dryRunFlag,
+						},
+					},
+					{
+						Name:   "reindex",
+						Usage:  "Rebuild the tag and day indexes used to query sessions",
+						Action: reindexAction,
+					},
+					{
+						Name:      "restore",
+						Usage:     "Replace the database with a backup",
+						UsageText: "focus db restore [OPTIONS] <file>",
+						Action:    restoreAction,
+						Flags: []cli.Flag{
+							yesFlag,
+						},
+					},
+				},
+			},
+			{
+				Name:   "delete",
+				Usage:  "Permanently delete the sessions in the specified time range",
+				Action: deleteAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					breaksFlag,
+					yesFlag,
+				},
+			},
 			{
 				Name:   "edit-config",
 				Usage:  "Edit the configuration file",
 				Action: editConfigAction,
 			},
+			{
+				Name:      "edit-tag",
+				Usage:     "Replace the tags of the sessions in the specified time range",
+				UsageText: "focus edit-tag [OPTIONS] <tag>[,<tag>...]",
+				Action:    editTagsAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					breaksFlag,
+					yesFlag,
+				},
+			},
+			{
+				Name:      "estimate",
+				Usage:     "Set or list the number of work sessions that tags are expected to take",
+				UsageText: "focus estimate [<tag>[,<tag>...] <sessions>]",
+				Action:    estimateAction,
+			},
+			{
+				Name:   "export",
+				Usage:  "Export the specified sessions to CSV, JSON Lines or iCalendar",
+				Action: exportAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					breaksFlag,
+					exportFormatFlag,
+				},
+			},
+			{
+				Name:      "import",
+				Usage:     "Import sessions from a CSV, JSON Lines or Toggl Track export",
+				UsageText: "focus import [OPTIONS] <file>",
+				Action:    importAction,
+				Flags: []cli.Flag{
+					importFormatFlag,
+					dryRunFlag,
+					yesFlag,
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List all the sessions within the specified time period",
+				Action: listAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					breaksFlag,
+					listJSONFlag,
+				},
+			},
+			{
+				Name:      "note",
+				Usage:     "Add a note to the most recent session or the specified sessions",
+				UsageText: "focus note [OPTIONS] <note>",
+				Action:    noteAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					breaksFlag,
+					intentFlag,
+					lastFlag,
+					yesFlag,
+				},
+			},
+			{
+				Name:      "resume",
+				Usage:     "Resume an interrupted work session",
+				UsageText: "focus resume [OPTIONS] [<number>]",
+				Action:    resumeAction,
+				Flags: []cli.Flag{
+					resetTimerFlag,
+					headlessFlag,
+					profileFlag,
+					maxSessionsFlag,
+					soundFlag,
+					soundOnBreakFlag,
+					workSoundFlag,
+					breakSoundFlag,
+					disableNotificationFlag,
+				},
+			},
 			{
 				Name: "stats",
 				Usage: `
 				Track your progress with detailed statistics reporting. Defaults to a 
 				reporting period of 7 days`,
 				Action: statsAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					statsFormatFlag,
+					statsJSONFlag,
+					statsPortFlag,
+				},
 			},
 			{
 				Name:   "status",
 				Usage:  "Print the status of the timer",
 				Action: statusAction,
+				Flags: []cli.Flag{
+					statusFormatFlag,
+					watchFlag,
+				},
+			},
+			{
+				Name:  "task",
+				Usage: "Manage the tasks that sessions are recorded against",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "add",
+						Usage:     "Add a new task",
+						UsageText: "focus task add [--estimate <n>] [--tag <tag>[,<tag>...]] <name>",
+						Action:    taskAddAction,
+						Flags: []cli.Flag{
+							estimateFlag,
+							taskTagFlag,
+						},
+					},
+					{
+						Name:      "archive",
+						Usage:     "Hide tasks from the task list and picker",
+						UsageText: "focus task archive <id>...",
+						Action:    taskArchiveAction,
+					},
+					{
+						Name:      "done",
+						Usage:     "Mark tasks as done",
+						UsageText: "focus task done <id>...",
+						Action:    taskDoneAction,
+					},
+					{
+						Name:   "list",
+						Usage:  "List tasks along with the work recorded against them",
+						Action: taskListAction,
+						Flags: []cli.Flag{
+							allTasksFlag,
+							taskJSONFlag,
+						},
+					},
+				},
 			},
 		},
 		Flags: []cli.Flag{
 			shortBreakFlag,
 			longBreakFlag,
 			longBreakIntervalFlag,
+			profileFlag,
+			maxSessionsFlag,
 			workFlag,
 			sinceFlag,
 			disableNotificationFlag,
@@ -68,7 +307,10 @@ func Get() *cli.App {
 			breakSoundFlag,
 			sessionCmdFlag,
 			addTagFlag,
+			tagEstimateFlag,
+			taskFlag,
 			strictFlag,
+			headlessFlag,
 		},
 		Action: defaultAction,
 		Before: beforeAction,
@@ -1,24 +1,25 @@
 package app
 
 import (
-	"bufio"
-	"fmt"
-	"os"
 	"time"
 
 	"github.com/pterm/pterm"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
 	"github.com/ayoisaiah/focus/store"
 )
 
-// delSessions deletes all the specified sessions. It requests for confirmation
-// before proceeding with the operation.
+// delSessions deletes all the specified sessions through a database connection
+// that is opened once the deletion is confirmed. It requests for confirmation
+// before proceeding with the operation unless skipConfirm is set.
 func delSessions(
-	db store.DB,
+	open func() (store.DB, error),
 	sessions []*models.Session,
+	skipConfirm bool,
 ) error {
 	if len(sessions) == 0 {
+		pterm.Info.Println(noSessionsMsg)
 		return nil
 	}
 
@@ -28,17 +29,21 @@ func delSessions(
 		t[i] = sessions[i].StartTime
 	}
 
-	printSessionsTable(os.Stdout, sessions)
+	printSessionsTable(config.Stdout, sessions)
 
-	warning := pterm.Warning.Sprint(
-		"The above sessions will be deleted permanently. Press ENTER to proceed",
-	)
-
-	fmt.Fprint(os.Stdout, warning)
+	if !skipConfirm {
+		err := confirm("The above sessions will be deleted permanently. Press ENTER to proceed")
+		if err != nil {
+			return err
+		}
+	}
 
-	reader := bufio.NewReader(os.Stdin)
+	db, err := open()
+	if err != nil {
+		return err
+	}
 
-	_, _ = reader.ReadString('\n')
+	defer db.Close()
 
 	return db.DeleteSessions(t)
 }
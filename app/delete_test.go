@@ -0,0 +1,106 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ayoisaiah/focus/store"
+)
+
+type DeleteTest struct {
+	Name        string
+	Input       string
+	Err         error
+	Remaining   int
+	Breaks      bool
+	SkipConfirm bool
+	// Opened reports whether the database is opened for writing
+	Opened bool
+}
+
+var deleteTestCases = []DeleteTest{
+	{
+		Name:        "Skip the confirmation with --yes",
+		SkipConfirm: true,
+		Remaining:   1,
+		Opened:      true,
+	},
+	{
+		Name:      "Confirm with ENTER",
+		Input:     "\n",
+		Remaining: 1,
+		Opened:    true,
+	},
+	{
+		Name:        "Breaks are deleted with --breaks",
+		SkipConfirm: true,
+		Breaks:      true,
+		Opened:      true,
+	},
+	{
+		Name:      "Cancel at the end of the input",
+		Remaining: 2,
+		Err:       errCancelled,
+	},
+}
+
+func TestDelSessions(t *testing.T) {
+	for _, tc := range deleteTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			setStdio(t, tc.Input)
+
+			db := testDB(t, testSessions())
+
+			sessions, err := db.GetSessions(exportStart, exportStart.Add(time.Hour), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := testContext(map[string]bool{"breaks": tc.Breaks})
+
+			var opened bool
+
+			open := func() (store.DB, error) {
+				opened = true
+				return db, nil
+			}
+
+			err = delSessions(open, excludeBreaks(ctx, sessions), tc.SkipConfirm)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error to be: %v, but got: %v", tc.Err, err)
+			}
+
+			if opened != tc.Opened {
+				t.Errorf("expected the database to be opened: %t, but got: %t", tc.Opened, opened)
+			}
+
+			remaining, err := db.GetSessions(exportStart, exportStart.Add(time.Hour), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(remaining) != tc.Remaining {
+				t.Errorf("expected %d remaining sessions, but got: %d", tc.Remaining, len(remaining))
+			}
+		})
+	}
+}
+
+func TestRequireTimeRange(t *testing.T) {
+	actions := map[string]cli.ActionFunc{
+		"delete":   deleteAction,
+		"edit-tag": editTagsAction,
+	}
+
+	for name, action := range actions {
+		t.Run(name, func(t *testing.T) {
+			err := action(testContext(nil))
+			if !errors.Is(err, errNoTimeRange) {
+				t.Errorf("expected error to be: %v, but got: %v", errNoTimeRange, err)
+			}
+		})
+	}
+}
@@ -1,47 +1,43 @@
 package app
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"time"
-
 	"github.com/pterm/pterm"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
 	"github.com/ayoisaiah/focus/store"
 )
 
-// editTags.edits the tags of the specified sessions.
+// editTags edits the tags of the specified sessions through a database
+// connection that is opened once the changes are confirmed. It requests for
+// confirmation before proceeding with the operation unless skipConfirm is set.
 func editTags(
-	db store.DB,
+	open func() (store.DB, error),
 	sessions []*models.Session,
 	args []string,
+	skipConfirm bool,
 ) error {
 	if len(sessions) == 0 {
 		pterm.Info.Println(noSessionsMsg)
 		return nil
 	}
 
-	m := make(map[time.Time]*models.Session)
-
-	for i := range sessions {
-		sessions[i].Tags = args
-
-		m[sessions[i].StartTime] = sessions[i]
+	setTags := func(sess *models.Session) {
+		sess.Tags = args
 	}
 
-	printSessionsTable(os.Stdout, sessions)
-
-	warning := pterm.Warning.Sprint(
-		"The sessions above will be updated. Press ENTER to proceed",
-	)
-
-	fmt.Fprint(os.Stdout, warning)
+	for _, sess := range sessions {
+		setTags(sess)
+	}
 
-	reader := bufio.NewReader(os.Stdin)
+	printSessionsTable(config.Stdout, sessions)
 
-	_, _ = reader.ReadString('\n')
+	if !skipConfirm {
+		err := confirm("The sessions above will be updated. Press ENTER to proceed")
+		if err != nil {
+			return err
+		}
+	}
 
-	return db.UpdateSessions(m)
+	return updateSessions(open, sessions, setTags)
 }
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type EditTagsTest struct {
+	Name        string
+	Input       string
+	Err         error
+	Expected    []string
+	SkipConfirm bool
+}
+
+var editTagsTestCases = []EditTagsTest{
+	{
+		Name:        "Skip the confirmation with --yes",
+		SkipConfirm: true,
+		Expected:    []string{"reading", "novel"},
+	},
+	{
+		Name:     "Confirm with ENTER",
+		Input:    "\n",
+		Expected: []string{"reading", "novel"},
+	},
+	{
+		Name:     "Cancel at the end of the input",
+		Expected: []string{"writing"},
+		Err:      errCancelled,
+	},
+}
+
+func TestEditTags(t *testing.T) {
+	for _, tc := range editTagsTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			setStdio(t, tc.Input)
+
+			db := testDB(t, testSessions())
+
+			sessions, err := db.GetSessions(exportStart, exportStart.Add(time.Hour), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := testContext(nil)
+
+			err = editTags(
+				testOpener(db),
+				excludeBreaks(ctx, sessions),
+				[]string{"reading", "novel"},
+				tc.SkipConfirm,
+			)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error to be: %v, but got: %v", tc.Err, err)
+			}
+
+			sess, err := db.GetSession(exportStart)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(sess.Tags, tc.Expected) {
+				t.Errorf("expected tags to be: %v, but got: %v", tc.Expected, sess.Tags)
+			}
+		})
+	}
+}
+
+func TestUpdateSessionsBolt(t *testing.T) {
+	db, err := store.NewClient(filepath.Join(t.TempDir(), "focus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	// sessions recorded outside UTC have an offset and fractional seconds in
+	// their keys
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 123456789, time.FixedZone("WAT", 3600))
+
+	var sessions []*models.Session
+
+	for i := range 2 {
+		sess := testSessions()[0]
+		sess.StartTime = start.Add(time.Duration(i) * time.Hour)
+		sess.EndTime = sess.StartTime.Add(sess.Duration)
+
+		sessions = append(sessions, sess)
+	}
+
+	err = db.UpdateSessions(map[time.Time]*models.Session{
+		sessions[0].StartTime: sessions[0],
+		sessions[1].StartTime: sessions[1],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open := func() (store.DB, error) {
+		return db, db.Open()
+	}
+
+	err = updateSessions(open, sessions, func(sess *models.Session) {
+		sess.Notes = "Finished the draft"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range sessions {
+		sess, err := db.GetSession(v.StartTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sess.Notes != "Finished the draft" {
+			t.Errorf("expected the session at %v to be updated, but got notes: %q", v.StartTime, sess.Notes)
+		}
+	}
+}
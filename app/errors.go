@@ -0,0 +1,101 @@
+package app
+
+import "github.com/ayoisaiah/focus/internal/apperr"
+
+var (
+	errCancelled = &apperr.Error{
+		Message: "the operation was cancelled",
+	}
+
+	errSessionNotSaved = &apperr.Error{
+		Message: "the latest changes to the session could not be saved",
+	}
+
+	errNoTags = &apperr.Error{
+		Message: "please provide one or more tags to apply to the matched sessions",
+	}
+
+	errNoTimeRange = &apperr.Error{
+		Message: "please select the sessions to change with the --period, --start, or --end options",
+	}
+
+	errNoNote = &apperr.Error{
+		Message: "please provide the note to add to the matched sessions, or an empty string to remove it",
+	}
+
+	errNoTaskName = &apperr.Error{
+		Message: "please provide the name of the task",
+	}
+
+	errNoTaskIDs = &apperr.Error{
+		Message: "invalid input: please provide the IDs of one or more tasks",
+	}
+
+	errTaskNotFound = &apperr.Error{
+		Message: "no open task matches the specified ID or name. See 'focus task list'",
+	}
+
+	errNoEstimateTags = &apperr.Error{
+		Message: "please provide the tags that the estimate applies to with --tag",
+	}
+
+	errInvalidEstimate = &apperr.Error{
+		Message: "invalid input: the estimate must be a number of work sessions, or 0 to remove it",
+	}
+
+	errInvalidIndex = &apperr.Error{
+		Message: "invalid input: the session number must be a positive integer",
+	}
+
+	errInvalidExportFormat = &apperr.Error{
+		Message: "invalid input: the export format must be one of csv, jsonl or ics",
+	}
+
+	errInvalidStatusFormat = &apperr.Error{
+		Message: "invalid input: the status format must be text, json, or a valid Go template",
+	}
+
+	errInvalidImportFormat = &apperr.Error{
+		Message: "invalid input: the import format must be one of csv, jsonl or toggl",
+	}
+
+	errImportNoStartTime = &apperr.Error{
+		Message: "the session has no start time",
+	}
+
+	errImportInvalidSessType = &apperr.Error{
+		Message: "unknown session type",
+	}
+
+	errImportInvalidDuration = &apperr.Error{
+		Message: "the session duration must be greater than zero",
+	}
+
+	errImportInvalidEndTime = &apperr.Error{
+		Message: "the session ends before it starts",
+	}
+
+	errImportInvalidTimeline = &apperr.Error{
+		Message: "the session timeline segments are out of order or outside the session",
+	}
+
+	errNoImportFile = &apperr.Error{
+		Message: "please provide the path to the file to import, or - for the standard input",
+	}
+
+	errInvalidStatsFormat = &apperr.Error{
+		Message: "invalid input: the stats format must be one of browser, terminal or json",
+	}
+
+	errNoBackupFile = &apperr.Error{
+		Message: "please provide the path to the backup to restore",
+	}
+
+	errBoltBackendOnly = &apperr.Error{
+		Message: "this command is only supported by the bbolt storage backend",
+	}
+
+	errSameBackend = &apperr.Error{
+		Message: "the database already uses the specified storage backend",
+	}
+)
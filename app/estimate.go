@@ -0,0 +1,105 @@
+package app
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/ui"
+	"github.com/ayoisaiah/focus/store"
+)
+
+// setTagEstimates sets the number of work sessions that the work on each of
+// the specified tags is expected to take. An estimate of zero removes the
+// current estimate.
+func setTagEstimates(db store.DB, tags []string, estimate int) error {
+	if len(tags) == 0 {
+		return errNoEstimateTags
+	}
+
+	now := time.Now()
+
+	err := db.BatchUpdate(func(b store.Batch) error {
+		for _, tag := range tags {
+			err := b.PutTagEstimate(&models.TagEstimate{
+				StartTime: now,
+				Tag:       tag,
+				Estimate:  estimate,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if estimate == 0 {
+			pterm.Success.Printfln("Removed the estimate for %s", tag)
+			continue
+		}
+
+		pterm.Success.Printfln(
+			"Set the estimate for %s to %d work sessions",
+			tag,
+			estimate,
+		)
+	}
+
+	return nil
+}
+
+// listTagEstimates prints the current estimate of each tag along with the work
+// sessions completed on the tag since the estimate was set.
+func listTagEstimates(db store.DB) error {
+	estimates, err := db.GetTagEstimates()
+	if err != nil {
+		return err
+	}
+
+	estimates = models.CurrentEstimates(estimates)
+
+	if len(estimates) == 0 {
+		pterm.Info.Println(
+			"No estimates found. Set one with `focus estimate <tag> <sessions>`",
+		)
+
+		return nil
+	}
+
+	sessions, err := db.GetSessions(estimates[0].StartTime, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+
+	tableBody := [][]string{
+		{"TAG", "SINCE", "COMPLETED", "ESTIMATE"},
+	}
+
+	for _, v := range estimates {
+		completed := v.CountCompleted(sessions, time.Time{})
+
+		done := strconv.Itoa(completed)
+		if completed > v.Estimate {
+			done = ui.Red(done)
+		}
+
+		tableBody = append(tableBody, []string{
+			v.Tag,
+			v.StartTime.Format("Jan 02, 2006 03:04 PM"),
+			done,
+			strconv.Itoa(v.Estimate),
+		})
+	}
+
+	ui.PrintTable(tableBody, os.Stdout)
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package app
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+const (
+	exportFormatCSV   = "csv"
+	exportFormatJSONL = "jsonl"
+	exportFormatICS   = "ics"
+)
+
+// csvHeader lists the columns of the CSV export. Each row represents a single
+// timeline segment of a session.
+var csvHeader = []string{
+	"start_time",
+	"end_time",
+	"session",
+	"type",
+	"tags",
+	"duration_secs",
+	"completed",
+	"skipped",
+	"intent",
+	"notes",
+	"segment",
+	"segment_start",
+	"segment_end",
+	"segment_secs",
+}
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	// icsLineLength is the maximum length of a content line in octets.
+	icsLineLength = 75
+)
+
+// formatTime formats a time in RFC3339, or returns an empty string for the
+// zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// writeCSV writes one row for each timeline segment of the provided sessions.
+func writeCSV(w io.Writer, sessions []*models.Session) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range sessions {
+		row := []string{
+			formatTime(sess.StartTime),
+			formatTime(sess.EndTime),
+			string(sess.Name),
+			sess.Type,
+			strings.Join(sess.Tags, ","),
+			strconv.Itoa(int(sess.Duration.Seconds())),
+			strconv.FormatBool(sess.Completed),
+			strconv.FormatBool(sess.Skipped),
+			sess.Intent,
+			sess.Notes,
+		}
+
+		// sessions without a timeline still get a row so that they are not
+		// lost in the export
+		if len(sess.Timeline) == 0 {
+			err = cw.Write(append(row, "", "", "", ""))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for i, v := range sess.Timeline {
+			err = cw.Write(append(slices.Clone(row),
+				strconv.Itoa(i+1),
+				formatTime(v.StartTime),
+				formatTime(v.EndTime),
+				strconv.Itoa(int(v.EndTime.Sub(v.StartTime).Seconds())),
+			))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeJSONL writes each session as a JSON object on its own line.
+func writeJSONL(w io.Writer, sessions []*models.Session) error {
+	enc := json.NewEncoder(w)
+
+	for _, sess := range sessions {
+		err := enc.Encode(sess)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// escapeICSText escapes special characters in an iCalendar text value.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldICSLine splits a content line into multiple lines of no more than 75
+// octets as required by RFC 5545. Continuation lines start with a space.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLength {
+		return line + "\r\n"
+	}
+
+	var b strings.Builder
+
+	limit := icsLineLength
+
+	for len(line) > limit {
+		i := limit
+		// avoid splitting a multi-byte character
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		b.WriteString(line[:i] + "\r\n ")
+
+		line = line[i:]
+		// the leading space counts towards the length of the next line
+		limit = icsLineLength - 1
+	}
+
+	b.WriteString(line + "\r\n")
+
+	return b.String()
+}
+
+// writeICS writes an iCalendar file containing an event for each timeline
+// segment of the provided sessions.
+func writeICS(w io.Writer, sessions []*models.Session) error {
+	bw := bufio.NewWriter(w)
+
+	write := func(lines ...string) {
+		for _, line := range lines {
+			_, _ = bw.WriteString(foldICSLine(line))
+		}
+	}
+
+	write(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Focus//Focus "+config.Version+"//EN",
+		"CALSCALE:GREGORIAN",
+	)
+
+	stamp := time.Now().UTC().Format(icsTimeFormat)
+
+	for _, sess := range sessions {
+		status := "completed"
+		if sess.Skipped {
+			status = "skipped"
+		} else if !sess.Completed {
+			status = "abandoned"
+		}
+
+		summary := sess.Label()
+		if len(sess.Tags) > 0 {
+			summary += " (" + strings.Join(sess.Tags, ", ") + ")"
+		}
+
+		description := "Status: " + status
+
+		if sess.Intent != "" {
+			description += "\nIntent: " + sess.Intent
+		}
+
+		if sess.Notes != "" {
+			description += "\nNotes: " + sess.Notes
+		}
+
+		timeline := sess.Timeline
+
+		// sessions without a timeline get a single event so that they are
+		// not lost in the export
+		if len(timeline) == 0 {
+			end := sess.EndTime
+			if end.IsZero() {
+				end = sess.StartTime.Add(sess.Duration)
+			}
+
+			timeline = []models.SessionTimeline{
+				{StartTime: sess.StartTime, EndTime: end},
+			}
+		}
+
+		for i, v := range timeline {
+			write(
+				"BEGIN:VEVENT",
+				// sessions are keyed by their start time with nanosecond
+				// precision, so the UID keeps it too to stay unique
+				fmt.Sprintf("UID:%d-%d@focus", sess.StartTime.UnixNano(), i+1),
+				"DTSTAMP:"+stamp,
+				"DTSTART:"+v.StartTime.UTC().Format(icsTimeFormat),
+				"DTEND:"+v.EndTime.UTC().Format(icsTimeFormat),
+				"SUMMARY:"+escapeICSText(summary),
+				"DESCRIPTION:"+escapeICSText(fmt.Sprintf(
+					"%s\nSegment %d of %d",
+					description,
+					i+1,
+					len(timeline),
+				)),
+			)
+
+			if len(sess.Tags) > 0 {
+				tags := make([]string, len(sess.Tags))
+				for j, tag := range sess.Tags {
+					tags[j] = escapeICSText(tag)
+				}
+
+				write("CATEGORIES:" + strings.Join(tags, ","))
+			}
+
+			write("END:VEVENT")
+		}
+	}
+
+	write("END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// exportSessions writes the provided sessions to w in the specified format.
+func exportSessions(
+	w io.Writer,
+	sessions []*models.Session,
+	format string,
+) error {
+	switch format {
+	case exportFormatCSV:
+		return writeCSV(w, sessions)
+	case exportFormatJSONL:
+		return writeJSONL(w, sessions)
+	case exportFormatICS:
+		return writeICS(w, sessions)
+	default:
+		return errInvalidExportFormat
+	}
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+type ExportTest struct {
+	Name     string
+	Format   string
+	Sessions []*models.Session
+	Contains []string
+	Lines    int
+}
+
+var exportStart = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+var interruptedSession = &models.Session{
+	Name:      config.Work,
+	StartTime: exportStart,
+	EndTime:   exportStart.Add(40 * time.Minute),
+	Duration:  25 * time.Minute,
+	Tags:      []string{"writing", "novel"},
+	Completed: true,
+	Timeline: []models.SessionTimeline{
+		{StartTime: exportStart, EndTime: exportStart.Add(10 * time.Minute)},
+		{
+			StartTime: exportStart.Add(25 * time.Minute),
+			EndTime:   exportStart.Add(40 * time.Minute),
+		},
+	},
+}
+
+var annotatedSession = &models.Session{
+	Name:      config.Work,
+	StartTime: exportStart,
+	EndTime:   exportStart.Add(25 * time.Minute),
+	Duration:  25 * time.Minute,
+	Intent:    "Outline chapter 3",
+	Notes:     "Done, but the ending needs work",
+	Completed: true,
+	Timeline: []models.SessionTimeline{
+		{StartTime: exportStart, EndTime: exportStart.Add(25 * time.Minute)},
+	},
+}
+
+var stretchSession = &models.Session{
+	Name:      config.ShortBreak,
+	Type:      "stretch",
+	StartTime: exportStart,
+	EndTime:   exportStart.Add(5 * time.Minute),
+	Duration:  5 * time.Minute,
+	Completed: true,
+	Timeline: []models.SessionTimeline{
+		{StartTime: exportStart, EndTime: exportStart.Add(5 * time.Minute)},
+	},
+}
+
+// untimedSession has no timeline, like sessions that were recorded before
+// timelines were introduced.
+var untimedSession = &models.Session{
+	Name:      config.Work,
+	StartTime: exportStart,
+	EndTime:   exportStart.Add(25 * time.Minute),
+	Duration:  25 * time.Minute,
+	Completed: true,
+}
+
+var exportTestCases = []ExportTest{
+	{
+		Name:     "CSV row for each timeline segment",
+		Format:   exportFormatCSV,
+		Sessions: []*models.Session{interruptedSession},
+		Contains: []string{
+			"2024-03-04T09:00:00Z,2024-03-04T09:40:00Z,Work session,,\"writing,novel\",1500,true,false,,,1,2024-03-04T09:00:00Z,2024-03-04T09:10:00Z,600",
+			"2024-03-04T09:00:00Z,2024-03-04T09:40:00Z,Work session,,\"writing,novel\",1500,true,false,,,2,2024-03-04T09:25:00Z,2024-03-04T09:40:00Z,900",
+		},
+		Lines: 3,
+	},
+	{
+		Name:     "CSV row with the intent and notes",
+		Format:   exportFormatCSV,
+		Sessions: []*models.Session{annotatedSession},
+		Contains: []string{
+			"1500,true,false,Outline chapter 3,\"Done, but the ending needs work\",1,",
+		},
+		Lines: 2,
+	},
+	{
+		Name:     "CSV row with a user-defined session type",
+		Format:   exportFormatCSV,
+		Sessions: []*models.Session{stretchSession},
+		Contains: []string{
+			"2024-03-04T09:05:00Z,Short break,stretch,,300,true,false,",
+		},
+		Lines: 2,
+	},
+	{
+		Name:     "JSON Lines object for each session",
+		Format:   exportFormatJSONL,
+		Sessions: []*models.Session{interruptedSession},
+		Contains: []string{`"tags":["writing","novel"]`},
+		Lines:    1,
+	},
+	{
+		Name:     "iCalendar event for each timeline segment",
+		Format:   exportFormatICS,
+		Sessions: []*models.Session{interruptedSession},
+		Contains: []string{
+			"UID:1709542800000000000-1@focus\r\n",
+			"DTSTART:20240304T092500Z\r\nDTEND:20240304T094000Z\r\n",
+			"SUMMARY:Work session (writing\\, novel)\r\n",
+			"CATEGORIES:writing,novel\r\n",
+		},
+		Lines: 23,
+	},
+	{
+		Name:   "iCalendar UID of a session that started within a second",
+		Format: exportFormatICS,
+		Sessions: []*models.Session{
+			importSession(500*time.Millisecond, 25*time.Minute),
+		},
+		Contains: []string{"UID:1709542800500000000-1@focus\r\n"},
+		Lines:    13,
+	},
+	{
+		Name:     "iCalendar event for a user-defined session type",
+		Format:   exportFormatICS,
+		Sessions: []*models.Session{stretchSession},
+		Contains: []string{"SUMMARY:stretch\r\n"},
+		Lines:    13,
+	},
+	{
+		Name:     "iCalendar event for a session without a timeline",
+		Format:   exportFormatICS,
+		Sessions: []*models.Session{untimedSession},
+		Contains: []string{
+			"DTSTART:20240304T090000Z\r\nDTEND:20240304T092500Z\r\n",
+			"Segment 1 of 1",
+		},
+		Lines: 13,
+	},
+}
+
+func TestExportSessions(t *testing.T) {
+	for _, tc := range exportTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := exportSessions(&buf, tc.Sessions, tc.Format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := buf.String()
+
+			for _, v := range tc.Contains {
+				if !strings.Contains(out, v) {
+					t.Errorf("expected output to contain: %q, but got: %q", v, out)
+				}
+			}
+
+			lines := strings.Count(out, "\n")
+			if lines != tc.Lines {
+				t.Errorf("expected %d lines, but got: %d", tc.Lines, lines)
+			}
+		})
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+
+	folded := foldICSLine(line)
+
+	for _, v := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(v) > icsLineLength {
+			t.Errorf("expected line to be at most %d octets, but got: %d", icsLineLength, len(v))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(folded, "\r\n ", "")
+	if unfolded != line+"\r\n" {
+		t.Errorf("expected unfolded line to be: %q, but got: %q", line, unfolded)
+	}
+}
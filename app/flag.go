@@ -13,9 +13,14 @@ var (
 		Usage: "Disable coloured output",
 	}
 
+	headlessFlag = &cli.BoolFlag{
+		Name:  "headless",
+		Usage: "Run the timer without a terminal interface. Use 'focus ctl' to control it",
+	}
+
 	strictFlag = &cli.BoolFlag{
 		Name:  "strict",
-		Usage: "When strict mode is enabled, you can't resume a paused session",
+		Usage: "When strict mode is enabled, sessions can't be paused, breaks can't be skipped,\n\t\t\t\tand interrupted sessions are abandoned instead of being resumable",
 	}
 
 	disableNotificationFlag = &cli.BoolFlag{
@@ -27,7 +32,7 @@ var (
 	sessionCmdFlag = &cli.StringFlag{
 		Name:    "session-cmd",
 		Aliases: []string{"cmd"},
-		Usage:   "Execute an arbitrary command after each session",
+		Usage:   "Execute an arbitrary command after each session. See the hooks config option for finer control",
 	}
 
 	soundFlag = &cli.StringFlag{
@@ -59,17 +64,142 @@ var (
 		Usage:   "Add comma-delimited tags to a session",
 	}
 
+	taskFlag = &cli.StringFlag{
+		Name:  "task",
+		Usage: "Record the sessions against a task by its ID or name. Use 'none' to skip the task\n\t\t\t\tpicker that is shown when there are open tasks",
+	}
+
+	taskTagFlag = &cli.StringFlag{
+		Name:    "tag",
+		Aliases: []string{"t"},
+		Usage:   "Add comma-delimited tags to the task. They are applied to sessions recorded against it",
+	}
+
+	estimateFlag = &cli.UintFlag{
+		Name:    "estimate",
+		Aliases: []string{"e"},
+		Usage:   "The number of work sessions the task is expected to take",
+	}
+
+	tagEstimateFlag = &cli.UintFlag{
+		Name:    "estimate",
+		Aliases: []string{"e"},
+		Usage:   "The number of work sessions that the tags in --tag are expected to take.\n\t\t\t\tUse 0 to remove the estimate",
+	}
+
+	allTasksFlag = &cli.BoolFlag{
+		Name:    "all",
+		Aliases: []string{"a"},
+		Usage:   "Include archived tasks",
+	}
+
+	taskJSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "List tasks in JSON format",
+	}
+
 	listJSONFlag = &cli.BoolFlag{
 		Name:  "json",
 		Usage: "List Focus sessions in JSON format",
 	}
 
+	periodFlag = &cli.StringFlag{
+		Name:    "period",
+		Aliases: []string{"p"},
+		Usage:   "Specify a time period (defaults to 7days). Possible values are: today, yesterday,\n\t\t\t\t7days, 14days, 30days, 90days, 180days, 365days, all-time",
+	}
+
+	startFlag = &cli.StringFlag{
+		Name:  "start",
+		Usage: "Match sessions that started on or after the specified date (e.g. '2024-01-20', '3 days ago')",
+	}
+
+	endFlag = &cli.StringFlag{
+		Name:  "end",
+		Usage: "Match sessions that started on or before the specified date (defaults to now)",
+	}
+
+	filterTagFlag = &cli.StringFlag{
+		Name:    "tag",
+		Aliases: []string{"t"},
+		Usage:   "Match only sessions with the specified tags. Separate alternatives with\n\t\t\t\ta comma, join required tags with +, and exclude tags with ! (e.g. work+urgent,!meeting)",
+	}
+
+	breaksFlag = &cli.BoolFlag{
+		Name:  "breaks",
+		Usage: "Include break sessions",
+	}
+
+	intentFlag = &cli.BoolFlag{
+		Name:  "intent",
+		Usage: "Set the intent of the sessions instead of their notes",
+	}
+
+	lastFlag = &cli.BoolFlag{
+		Name:  "last",
+		Usage: "Match only the most recent session",
+	}
+
+	yesFlag = &cli.BoolFlag{
+		Name:    "yes",
+		Aliases: []string{"y"},
+		Usage:   "Proceed without asking for confirmation",
+	}
+
+	exportFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   "Specify the export format. Possible values are: csv, jsonl, ics",
+		Value:   exportFormatCSV,
+	}
+
+	importFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   "Specify the import format (defaults to the file extension). Possible values\n\t\t\t\tare: csv, jsonl, toggl",
+	}
+
+	migrateToFlag = &cli.StringFlag{
+		Name:  "to",
+		Usage: "Copy all sessions to the specified storage backend (bbolt or jsonl)",
+	}
+
+	dryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Show the changes that would be made without applying them",
+	}
+
+	statsJSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Output the statistics in JSON format (same as --format json)",
+	}
+
+	statsFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   "Specify where the statistics are displayed. Possible values are: browser,\n\t\t\t\tterminal, json",
+		Value:   statsFormatBrowser,
+	}
+
 	statsPortFlag = &cli.UintFlag{
 		Name:  "port",
 		Usage: "Specify the port for the statistics server",
 		Value: 1111,
 	}
 
+	statusFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   "Specify the status format. Possible values are: text, json, or a Go template\n\t\t\t\t(e.g. '{{.Name}} {{clock .Remaining}}')",
+		Value:   statusFormatText,
+	}
+
+	watchFlag = &cli.BoolFlag{
+		Name:    "watch",
+		Aliases: []string{"w"},
+		Usage:   "Print the status every second",
+	}
+
 	resetTimerFlag = &cli.BoolFlag{
 		Name:    "reset",
 		Aliases: []string{"r"},
@@ -95,6 +225,17 @@ var (
 		Usage:   "The number of work sessions before a long break (default: 4)",
 	}
 
+	profileFlag = &cli.StringFlag{
+		Name:  "profile",
+		Usage: "Repeat the cycle of sessions defined by the specified profile in the config file,\n\t\t\t\tor alternate between work and break minutes in a ratio such as 52/17",
+	}
+
+	maxSessionsFlag = &cli.UintFlag{
+		Name:    "max-sessions",
+		Aliases: []string{"max"},
+		Usage:   "The number of work sessions after which the timer exits",
+	}
+
 	workFlag = &cli.StringFlag{
 		Name:    "work",
 		Aliases: []string{"w"},
@@ -0,0 +1,544 @@
+package app
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+const (
+	importFormatToggl = "toggl"
+
+	// togglTimeFormat is the layout of the combined date and time columns in
+	// a Toggl Track detailed report.
+	togglTimeFormat = "2006-01-02 15:04:05"
+)
+
+type (
+	// importer parses sessions from a file in a specific format.
+	importer func(r io.Reader) ([]*models.Session, error)
+
+	// csvRecord maps the column names of a CSV file to the values in a row.
+	csvRecord map[string]string
+)
+
+// importers contains the supported import formats.
+var importers = map[string]importer{
+	exportFormatCSV:   readCSV,
+	exportFormatJSONL: readJSONL,
+	importFormatToggl: readTogglCSV,
+}
+
+// readCSVRecords reads the rows of a CSV file with a header row. Column names
+// are matched case-insensitively and a leading byte order mark is ignored.
+func readCSVRecords(r io.Reader) ([]csvRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	for i := range header {
+		header[i] = strings.ToLower(
+			strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")),
+		)
+	}
+
+	var records []csvRecord
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		rec := make(csvRecord, len(header))
+
+		for i, v := range row {
+			if i < len(header) {
+				rec[header[i]] = strings.TrimSpace(v)
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// parseTags splits a comma-separated list of tags.
+func parseTags(s string) []string {
+	var tags []string
+
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// parseOptionalTime parses an RFC3339 time, allowing empty values.
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+// readCSV reads sessions in the format produced by `focus export --format csv`
+// where each row is a timeline segment of a session.
+func readCSV(r io.Reader) ([]*models.Session, error) {
+	records, err := readCSVRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*models.Session
+
+	index := make(map[string]*models.Session)
+
+	for i, rec := range records {
+		// the header is the first line
+		line := i + 2
+
+		sess, ok := index[rec["start_time"]]
+		if !ok {
+			sess = &models.Session{
+				Name: config.SessType(rec["session"]),
+				Type: rec["type"],
+				Tags: parseTags(rec["tags"]),
+			}
+
+			sess.StartTime, err = time.Parse(time.RFC3339, rec["start_time"])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			sess.EndTime, err = parseOptionalTime(rec["end_time"])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			secs, err := strconv.Atoi(rec["duration_secs"])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			sess.Duration = time.Duration(secs) * time.Second
+
+			sess.Completed, _ = strconv.ParseBool(rec["completed"])
+			sess.Skipped, _ = strconv.ParseBool(rec["skipped"])
+			sess.Intent = rec["intent"]
+			sess.Notes = rec["notes"]
+
+			index[rec["start_time"]] = sess
+
+			sessions = append(sessions, sess)
+		}
+
+		if rec["segment_start"] == "" {
+			continue
+		}
+
+		var segment models.SessionTimeline
+
+		segment.StartTime, err = time.Parse(time.RFC3339, rec["segment_start"])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		segment.EndTime, err = parseOptionalTime(rec["segment_end"])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		sess.Timeline = append(sess.Timeline, segment)
+	}
+
+	return sessions, nil
+}
+
+// readJSONL reads sessions in the format produced by
+// `focus export --format jsonl`.
+func readJSONL(r io.Reader) ([]*models.Session, error) {
+	var sessions []*models.Session
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	var line int
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var sess models.Session
+
+		err := json.Unmarshal([]byte(text), &sess)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		sessions = append(sessions, &sess)
+	}
+
+	return sessions, scanner.Err()
+}
+
+// readTogglCSV reads time entries from a Toggl Track detailed report. Each
+// entry is imported as a completed work session tagged with its project and
+// tags.
+func readTogglCSV(r io.Reader) ([]*models.Session, error) {
+	records, err := readCSVRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*models.Session, 0, len(records))
+
+	for i, rec := range records {
+		line := i + 2
+
+		start, err := time.ParseInLocation(
+			togglTimeFormat,
+			rec["start date"]+" "+rec["start time"],
+			time.Local,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		end, err := time.ParseInLocation(
+			togglTimeFormat,
+			rec["end date"]+" "+rec["end time"],
+			time.Local,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		tags := parseTags(rec["tags"])
+		if rec["project"] != "" && !slices.Contains(tags, rec["project"]) {
+			tags = append([]string{rec["project"]}, tags...)
+		}
+
+		sessions = append(sessions, &models.Session{
+			Name:      config.Work,
+			StartTime: start,
+			EndTime:   end,
+			Duration:  end.Sub(start),
+			Tags:      tags,
+			Completed: true,
+			Timeline: []models.SessionTimeline{
+				{StartTime: start, EndTime: end},
+			},
+		})
+	}
+
+	return sessions, nil
+}
+
+// validateSession reports whether an imported session can be stored safely.
+func validateSession(sess *models.Session) error {
+	if sess.StartTime.IsZero() {
+		return errImportNoStartTime
+	}
+
+	if !slices.Contains(
+		[]config.SessType{config.Work, config.ShortBreak, config.LongBreak},
+		sess.Name,
+	) {
+		return fmt.Errorf("%w: %q", errImportInvalidSessType, sess.Name)
+	}
+
+	if sess.Duration <= 0 {
+		return errImportInvalidDuration
+	}
+
+	if !sess.EndTime.IsZero() && sess.EndTime.Before(sess.StartTime) {
+		return errImportInvalidEndTime
+	}
+
+	// sessions without a timeline are exported too, so they are accepted
+	for i, v := range sess.Timeline {
+		if v.EndTime.Before(v.StartTime) || v.StartTime.Before(sess.StartTime) {
+			return errImportInvalidTimeline
+		}
+
+		if i > 0 && v.StartTime.Before(sess.Timeline[i-1].EndTime) {
+			return errImportInvalidTimeline
+		}
+	}
+
+	return nil
+}
+
+// sessionEnd returns the end time of a session, falling back to the end of its
+// last timeline segment for sessions that were not ended gracefully.
+func sessionEnd(sess *models.Session) time.Time {
+	if !sess.EndTime.IsZero() {
+		return sess.EndTime
+	}
+
+	if len(sess.Timeline) > 0 {
+		return sess.Timeline[len(sess.Timeline)-1].EndTime
+	}
+
+	return sess.StartTime
+}
+
+// overlaps reports whether two sessions share any period of time. Sessions
+// that start at the same time always overlap as they share a database key.
+func overlaps(a, b *models.Session) bool {
+	if a.StartTime.Equal(b.StartTime) {
+		return true
+	}
+
+	return a.StartTime.Before(sessionEnd(b)) && b.StartTime.Before(sessionEnd(a))
+}
+
+// partitionOverlaps separates the imported sessions that can be added from
+// those that overlap with existing sessions or with an earlier session in the
+// same import.
+func partitionOverlaps(
+	existing, imported []*models.Session,
+) (accepted, rejected []*models.Session) {
+	byStart := func(a, b *models.Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	}
+
+	saved := slices.Clone(existing)
+	slices.SortFunc(saved, byStart)
+
+	// existing sessions may overlap each other, so the latest end of the
+	// sessions up to each index is tracked to find any earlier session that
+	// is still active
+	latestEnd := make([]time.Time, len(saved))
+
+	for i, sess := range saved {
+		latestEnd[i] = sessionEnd(sess)
+		if i > 0 && latestEnd[i-1].After(latestEnd[i]) {
+			latestEnd[i] = latestEnd[i-1]
+		}
+	}
+
+	slices.SortStableFunc(imported, byStart)
+
+	for _, sess := range imported {
+		i, _ := slices.BinarySearchFunc(saved, sess, byStart)
+
+		// accepted sessions are sorted and don't overlap each other, so only
+		// the last one can overlap a session that starts later
+		if (i > 0 && latestEnd[i-1].After(sess.StartTime)) ||
+			(i < len(saved) && overlaps(saved[i], sess)) ||
+			(len(accepted) > 0 && overlaps(accepted[len(accepted)-1], sess)) {
+			rejected = append(rejected, sess)
+			continue
+		}
+
+		accepted = append(accepted, sess)
+	}
+
+	return accepted, rejected
+}
+
+// importFormat determines the format of the import file from the provided
+// format or the file extension.
+func importFormat(format, path string) (importer, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	fn, ok := importers[format]
+	if !ok {
+		return nil, errInvalidImportFormat
+	}
+
+	return fn, nil
+}
+
+// readImportFile reads and validates the sessions in the specified file, or
+// the standard input if the path is "-".
+func readImportFile(path, format string) ([]*models.Session, error) {
+	read, err := importFormat(format, path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := io.Reader(os.Stdin)
+
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	sessions, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sess := range sessions {
+		err = validateSession(sess)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"session %d (%s): %w",
+				i+1,
+				sess.StartTime.Format(time.RFC3339),
+				err,
+			)
+		}
+	}
+
+	return sessions, nil
+}
+
+// importSessions adds the provided sessions to the database in a single
+// transaction, skipping any that overlap with existing sessions. The existing
+// sessions are retrieved through a read-only connection, and the database is
+// only opened for writing once the import is confirmed. Nothing is written if
+// dryRun is set. It requests for confirmation before proceeding unless
+// skipConfirm is set.
+func importSessions(
+	openRead, openWrite func() (store.DB, error),
+	sessions []*models.Session,
+	dryRun, skipConfirm bool,
+) error {
+	if len(sessions) == 0 {
+		pterm.Info.Println("No sessions found in the import file")
+		return nil
+	}
+
+	last := slices.MaxFunc(sessions, func(a, b *models.Session) int {
+		return sessionEnd(a).Compare(sessionEnd(b))
+	})
+
+	// an existing session that started long before the first imported session
+	// may still overlap it, so all the earlier sessions are checked
+	existingSessions := func(db store.DB) ([]*models.Session, error) {
+		return db.GetSessions(time.Time{}, sessionEnd(last), nil)
+	}
+
+	db, err := openRead()
+	if err != nil {
+		return err
+	}
+
+	existing, err := existingSessions(db)
+
+	db.Close()
+
+	if err != nil {
+		return err
+	}
+
+	accepted, rejected := partitionOverlaps(existing, sessions)
+
+	if len(rejected) > 0 {
+		pterm.Warning.Printfln(
+			"%d session(s) overlap with other sessions and will be skipped:",
+			len(rejected),
+		)
+
+		printSessionsTable(config.Stdout, rejected)
+	}
+
+	if len(accepted) == 0 {
+		pterm.Info.Println("There are no new sessions to import")
+		return nil
+	}
+
+	printSessionsTable(config.Stdout, accepted)
+
+	if dryRun {
+		pterm.Info.Printfln(
+			"%d session(s) would be imported (dry run)",
+			len(accepted),
+		)
+
+		return nil
+	}
+
+	if !skipConfirm {
+		err = confirm("The sessions above will be imported. Press ENTER to proceed")
+		if err != nil {
+			return err
+		}
+	}
+
+	db, err = openWrite()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	// an active timer may have saved a session that overlaps with the
+	// imported sessions in the meantime
+	existing, err = existingSessions(db)
+	if err != nil {
+		return err
+	}
+
+	accepted, rejected = partitionOverlaps(existing, accepted)
+
+	m := make(map[time.Time]*models.Session, len(accepted))
+
+	for _, sess := range accepted {
+		m[sess.StartTime] = sess
+	}
+
+	err = db.UpdateSessions(m)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("%d session(s) imported", len(accepted))
+
+	if len(rejected) > 0 {
+		pterm.Warning.Printfln(
+			"%d session(s) were skipped as they now overlap with other sessions",
+			len(rejected),
+		)
+	}
+
+	return nil
+}
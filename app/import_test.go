@@ -0,0 +1,167 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+type OverlapTest struct {
+	Name     string
+	Existing []*models.Session
+	Imported []*models.Session
+	Accepted int
+	Rejected int
+}
+
+func importSession(offset, duration time.Duration) *models.Session {
+	start := exportStart.Add(offset)
+	end := start.Add(duration)
+
+	return &models.Session{
+		Name:      config.Work,
+		StartTime: start,
+		EndTime:   end,
+		Duration:  duration,
+		Completed: true,
+		Timeline: []models.SessionTimeline{
+			{StartTime: start, EndTime: end},
+		},
+	}
+}
+
+var overlapTestCases = []OverlapTest{
+	{
+		Name: "No existing sessions",
+		Imported: []*models.Session{
+			importSession(0, 25*time.Minute),
+			importSession(30*time.Minute, 25*time.Minute),
+		},
+		Accepted: 2,
+	},
+	{
+		Name: "Identical session already exists",
+		Existing: []*models.Session{
+			importSession(0, 25*time.Minute),
+		},
+		Imported: []*models.Session{
+			importSession(0, 25*time.Minute),
+		},
+		Rejected: 1,
+	},
+	{
+		Name: "Partial overlap with an existing session",
+		Existing: []*models.Session{
+			importSession(time.Hour, 25*time.Minute),
+		},
+		Imported: []*models.Session{
+			importSession(0, 25*time.Minute),
+			importSession(50*time.Minute, 25*time.Minute),
+			importSession(90*time.Minute, 25*time.Minute),
+		},
+		Accepted: 2,
+		Rejected: 1,
+	},
+	{
+		Name: "Overlap within the imported sessions",
+		Imported: []*models.Session{
+			importSession(10*time.Minute, 25*time.Minute),
+			importSession(0, 25*time.Minute),
+		},
+		Accepted: 1,
+		Rejected: 1,
+	},
+	{
+		Name: "Adjacent sessions do not overlap",
+		Existing: []*models.Session{
+			importSession(25*time.Minute, 5*time.Minute),
+		},
+		Imported: []*models.Session{
+			importSession(0, 25*time.Minute),
+			importSession(30*time.Minute, 25*time.Minute),
+		},
+		Accepted: 2,
+	},
+	{
+		Name: "Overlap with a long session that started earlier",
+		Existing: []*models.Session{
+			importSession(0, 3*time.Hour),
+			importSession(10*time.Minute, 5*time.Minute),
+		},
+		Imported: []*models.Session{
+			importSession(time.Hour, 25*time.Minute),
+			importSession(3*time.Hour, 25*time.Minute),
+		},
+		Accepted: 1,
+		Rejected: 1,
+	},
+}
+
+func TestPartitionOverlaps(t *testing.T) {
+	for _, tc := range overlapTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			accepted, rejected := partitionOverlaps(tc.Existing, tc.Imported)
+
+			if len(accepted) != tc.Accepted || len(rejected) != tc.Rejected {
+				t.Errorf(
+					"expected %d accepted and %d rejected, but got: %d and %d",
+					tc.Accepted,
+					tc.Rejected,
+					len(accepted),
+					len(rejected),
+				)
+			}
+		})
+	}
+}
+
+func TestImportExportRoundTrip(t *testing.T) {
+	for _, format := range []string{exportFormatCSV, exportFormatJSONL} {
+		for _, want := range []*models.Session{interruptedSession, untimedSession} {
+			name := fmt.Sprintf("%s with %d segments", format, len(want.Timeline))
+
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+
+				err := exportSessions(&buf, []*models.Session{want}, format)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				sessions, err := importers[format](&buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(sessions) != 1 {
+					t.Fatalf("expected 1 session, but got: %d", len(sessions))
+				}
+
+				got := sessions[0]
+
+				err = validateSession(got)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !got.StartTime.Equal(want.StartTime) ||
+					!got.EndTime.Equal(want.EndTime) ||
+					got.Duration != want.Duration ||
+					got.Completed != want.Completed ||
+					!reflect.DeepEqual(got.Tags, want.Tags) ||
+					len(got.Timeline) != len(want.Timeline) {
+					t.Errorf(
+						"expected session to be: %+v, but got: %+v",
+						want,
+						got,
+					)
+				}
+			})
+		}
+	}
+}
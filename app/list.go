@@ -1,13 +1,16 @@
 package app
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/pterm/pterm"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
 	"github.com/ayoisaiah/focus/internal/ui"
 )
@@ -16,15 +19,46 @@ const (
 	noSessionsMsg = "No sessions found for the specified time range"
 )
 
-// printSessionsTable prints a session table to the command-line.
+// maxNoteLength is the maximum number of characters of a note shown in the
+// session table.
+const maxNoteLength = 40
+
+// truncateNote shortens a note so that it fits in the session table.
+func truncateNote(note string) string {
+	runes := []rune(note)
+	if len(runes) <= maxNoteLength {
+		return note
+	}
+
+	return strings.TrimSpace(string(runes[:maxNoteLength-1])) + "…"
+}
+
+// printSessionsTable prints a session table to the command-line. A session
+// column is included if any of the sessions is a break, and the intent and
+// notes columns are included if any of the sessions has them.
 func printSessionsTable(w io.Writer, sessions []*models.Session) {
 	tableBody := make([][]string, len(sessions))
 
+	// the session column is shown for breaks and user-defined session types
+	withBreaks := slices.ContainsFunc(sessions, func(s *models.Session) bool {
+		return s.IsBreak() || s.Label() != string(s.Name)
+	})
+
+	withIntent := slices.ContainsFunc(sessions, func(s *models.Session) bool {
+		return s.Intent != ""
+	})
+
+	withNotes := slices.ContainsFunc(sessions, func(s *models.Session) bool {
+		return s.Notes != ""
+	})
+
 	for i := range sessions {
 		sess := sessions[i]
 
 		statusText := ui.Green("completed")
-		if !sess.Completed {
+		if sess.Skipped {
+			statusText = ui.Magenta("skipped")
+		} else if !sess.Completed {
 			statusText = ui.Red("abandoned")
 		}
 
@@ -43,24 +77,63 @@ func printSessionsTable(w io.Writer, sessions []*models.Session) {
 			statusText,
 		}
 
+		if withBreaks {
+			row = slices.Insert(row, 1, sess.Label())
+		}
+
+		if withIntent {
+			row = append(row, truncateNote(sess.Intent))
+		}
+
+		if withNotes {
+			row = append(row, truncateNote(sess.Notes))
+		}
+
 		tableBody[i] = row
 	}
 
-	tableBody = append([][]string{
-		{"#", "START DATE", "END DATE", "TAGS", "STATUS"},
-	}, tableBody...)
+	header := []string{"#", "START DATE", "END DATE", "TAGS", "STATUS"}
+	if withBreaks {
+		header = slices.Insert(header, 1, "SESSION")
+	}
+
+	if withIntent {
+		header = append(header, "INTENT")
+	}
+
+	if withNotes {
+		header = append(header, "NOTES")
+	}
+
+	tableBody = append([][]string{header}, tableBody...)
 
 	ui.PrintTable(tableBody, w)
 }
 
-// listSessions prints out a table of sessions.
-func listSessions(sessions []*models.Session) error {
+// listSessions prints out a table of sessions, or a JSON array of the sessions
+// if asJSON is set.
+func listSessions(sessions []*models.Session, asJSON bool) error {
+	if asJSON {
+		if sessions == nil {
+			sessions = []*models.Session{}
+		}
+
+		b, err := json.Marshal(sessions)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(config.Stdout, string(b))
+
+		return nil
+	}
+
 	if len(sessions) == 0 {
 		pterm.Info.Println(noSessionsMsg)
 		return nil
 	}
 
-	printSessionsTable(os.Stdout, sessions)
+	printSessionsTable(config.Stdout, sessions)
 
 	return nil
 }
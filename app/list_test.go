@@ -0,0 +1,170 @@
+package app
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type ListTest struct {
+	Name     string
+	Sessions []*models.Session
+	Breaks   bool
+	JSON     bool
+	Contains []string
+	Excludes []string
+}
+
+// testSessions returns a completed work session followed by a short break.
+func testSessions() []*models.Session {
+	return []*models.Session{
+		{
+			Name:      config.Work,
+			StartTime: exportStart,
+			EndTime:   exportStart.Add(25 * time.Minute),
+			Duration:  25 * time.Minute,
+			Tags:      []string{"writing"},
+			Completed: true,
+		},
+		{
+			Name:      config.ShortBreak,
+			StartTime: exportStart.Add(25 * time.Minute),
+			EndTime:   exportStart.Add(30 * time.Minute),
+			Duration:  5 * time.Minute,
+			Completed: true,
+		},
+	}
+}
+
+// testDB returns an in-memory store that contains the provided sessions.
+func testDB(t *testing.T, sessions []*models.Session) store.DB {
+	t.Helper()
+
+	db := store.NewMemory()
+
+	m := make(map[time.Time]*models.Session)
+
+	for _, sess := range sessions {
+		m[sess.StartTime] = sess
+	}
+
+	err := db.UpdateSessions(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// testOpener returns a function that opens the provided database.
+func testOpener(db store.DB) func() (store.DB, error) {
+	return func() (store.DB, error) {
+		return db, nil
+	}
+}
+
+// testContext returns a command context with the specified boolean flags.
+func testContext(flags map[string]bool) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	for k, v := range flags {
+		set.Bool(k, v, "")
+	}
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+// setStdio replaces the standard input with the provided input until the end
+// of the test, and returns the buffer that the standard output is written to.
+func setStdio(t *testing.T, input string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	stdin, stdout := config.Stdin, config.Stdout
+	config.Stdin, config.Stdout = strings.NewReader(input), &buf
+
+	t.Cleanup(func() {
+		config.Stdin, config.Stdout = stdin, stdout
+	})
+
+	return &buf
+}
+
+var listTestCases = []ListTest{
+	{
+		Name:     "Breaks are excluded by default",
+		Sessions: testSessions(),
+		JSON:     true,
+		Contains: []string{`"name":"Work session"`},
+		Excludes: []string{`"name":"Short break"`},
+	},
+	{
+		Name:     "Breaks are included with --breaks",
+		Sessions: testSessions(),
+		Breaks:   true,
+		JSON:     true,
+		Contains: []string{`"name":"Work session"`, `"name":"Short break"`},
+	},
+	{
+		Name:     "No sessions as JSON",
+		JSON:     true,
+		Contains: []string{"[]"},
+	},
+	{
+		Name:     "Table with a session column for breaks",
+		Sessions: testSessions(),
+		Breaks:   true,
+		Contains: []string{"SESSION", "Short break", "writing"},
+	},
+	{
+		Name: "Table with the name of a user-defined session type",
+		Sessions: []*models.Session{
+			{
+				Name:      config.Work,
+				Type:      "deep",
+				StartTime: exportStart,
+				EndTime:   exportStart.Add(90 * time.Minute),
+				Duration:  90 * time.Minute,
+				Completed: true,
+			},
+		},
+		Contains: []string{"SESSION", "deep"},
+		Excludes: []string{"Work session"},
+	},
+}
+
+func TestListSessions(t *testing.T) {
+	for _, tc := range listTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := setStdio(t, "")
+
+			ctx := testContext(map[string]bool{"breaks": tc.Breaks})
+
+			err := listSessions(excludeBreaks(ctx, tc.Sessions), tc.JSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range tc.Contains {
+				if !strings.Contains(out.String(), v) {
+					t.Errorf("expected output to contain: %q, but got: %q", v, out)
+				}
+			}
+
+			for _, v := range tc.Excludes {
+				if strings.Contains(out.String(), v) {
+					t.Errorf("expected output not to contain: %q, but got: %q", v, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+// editNotes replaces the notes, or the intent if intent is set, of the
+// specified sessions through a database connection that is opened once the
+// changes are confirmed. An empty note clears the existing one. It requests for
+// confirmation before proceeding with the operation unless skipConfirm is set.
+func editNotes(
+	open func() (store.DB, error),
+	sessions []*models.Session,
+	note string,
+	intent, skipConfirm bool,
+) error {
+	if len(sessions) == 0 {
+		pterm.Info.Println(noSessionsMsg)
+		return nil
+	}
+
+	setNote := func(sess *models.Session) {
+		if intent {
+			sess.Intent = note
+		} else {
+			sess.Notes = note
+		}
+	}
+
+	for _, sess := range sessions {
+		setNote(sess)
+	}
+
+	printSessionsTable(config.Stdout, sessions)
+
+	if !skipConfirm {
+		err := confirm("The sessions above will be updated. Press ENTER to proceed")
+		if err != nil {
+			return err
+		}
+	}
+
+	return updateSessions(open, sessions, setNote)
+}
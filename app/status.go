@@ -0,0 +1,136 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/report"
+	"github.com/ayoisaiah/focus/timer"
+)
+
+const (
+	statusFormatText = "text"
+	statusFormatJSON = "json"
+)
+
+// statusTemplateFuncs are the functions available to custom status formats.
+var statusTemplateFuncs = template.FuncMap{
+	"clock": formatClock,
+	"join":  strings.Join,
+}
+
+// formatClock formats a duration as MM:SS.
+func formatClock(d time.Duration) string {
+	total := int(d.Round(time.Second).Seconds())
+
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+// statusNames are the short names of the built-in sessions in the status
+// text.
+var statusNames = map[config.SessType]string{
+	config.Work:       "Work",
+	config.ShortBreak: "Short break",
+	config.LongBreak:  "Long break",
+}
+
+// statusText describes the status in a single line such as
+// "[Work 1/4]: 12:34". The name of a user-defined session type is shown in
+// place of the built-in session that it is based on.
+func statusText(s *report.Status) string {
+	kind := config.SessType(s.Kind)
+
+	name := s.Name
+	if name == s.Kind {
+		name = statusNames[kind]
+	}
+
+	text := "[" + name
+
+	if kind == config.Work {
+		text += fmt.Sprintf(" %d/%d", s.WorkCycle, s.LongBreakInterval)
+	}
+
+	text += "]: " + formatClock(s.Remaining)
+
+	switch {
+	case s.Paused:
+		text += " (paused)"
+	case s.State == report.StateWaiting:
+		text += " (waiting)"
+	}
+
+	return text
+}
+
+// statusFormatter returns a function that renders the status in the specified
+// format, which is text, json, or a Go template.
+func statusFormatter(format string) (func(s *report.Status) (string, error), error) {
+	switch format {
+	case "", statusFormatText:
+		return func(s *report.Status) (string, error) {
+			return statusText(s), nil
+		}, nil
+	case statusFormatJSON:
+		return func(s *report.Status) (string, error) {
+			b, err := json.Marshal(s)
+
+			return string(b), err
+		}, nil
+	}
+
+	tmpl, err := template.New("status").Funcs(statusTemplateFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidStatusFormat, err)
+	}
+
+	return func(s *report.Status) (string, error) {
+		var b strings.Builder
+
+		err := tmpl.Execute(&b, s)
+
+		return b.String(), err
+	}, nil
+}
+
+// printStatus prints the status of the active timer in the provided format.
+// Nothing is printed if no timer is active unless watch is set, in which case
+// an empty line is printed so that status bars are cleared. Failures to read
+// the status are also treated as an inactive timer in watch mode.
+func printStatus(
+	w io.Writer,
+	render func(s *report.Status) (string, error),
+	watch bool,
+) error {
+	s, err := timer.ReadStatus()
+	if err != nil {
+		if !watch {
+			return err
+		}
+
+		slog.Error("unable to read timer status", slog.Any("error", err))
+	}
+
+	if s == nil {
+		if watch {
+			fmt.Fprintln(w)
+		}
+
+		return nil
+	}
+
+	out, err := render(s)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, out)
+
+	return nil
+}
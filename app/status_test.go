@@ -0,0 +1,117 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/report"
+)
+
+type StatusFormatTest struct {
+	Name     string
+	Format   string
+	Status   report.Status
+	Expected string
+	Err      error
+}
+
+var statusFormatTestCases = []StatusFormatTest{
+	{
+		Name:   "Work session as text",
+		Format: statusFormatText,
+		Status: report.Status{
+			Name:              string(config.Work),
+			Kind:              string(config.Work),
+			WorkCycle:         2,
+			LongBreakInterval: 4,
+			Remaining:         12*time.Minute + 34*time.Second,
+		},
+		Expected: "[Work 2/4]: 12:34",
+	},
+	{
+		Name:   "Paused session as text",
+		Format: statusFormatText,
+		Status: report.Status{
+			Name:      string(config.ShortBreak),
+			Kind:      string(config.ShortBreak),
+			Remaining: 3 * time.Minute,
+			Paused:    true,
+		},
+		Expected: "[Short break]: 03:00 (paused)",
+	},
+	{
+		Name:   "Waiting for the next session as text",
+		Format: statusFormatText,
+		Status: report.Status{
+			Name:      string(config.ShortBreak),
+			Kind:      string(config.ShortBreak),
+			Remaining: 5 * time.Minute,
+			State:     report.StateWaiting,
+		},
+		Expected: "[Short break]: 05:00 (waiting)",
+	},
+	{
+		Name:   "User-defined session type as text",
+		Format: statusFormatText,
+		Status: report.Status{
+			Name:              "deep-work",
+			Kind:              string(config.Work),
+			WorkCycle:         1,
+			LongBreakInterval: 3,
+			Remaining:         45 * time.Minute,
+		},
+		Expected: "[deep-work 1/3]: 45:00",
+	},
+	{
+		Name:   "User-defined break as text",
+		Format: statusFormatText,
+		Status: report.Status{
+			Name:      "stretch",
+			Kind:      string(config.ShortBreak),
+			Remaining: 2 * time.Minute,
+		},
+		Expected: "[stretch]: 02:00",
+	},
+	{
+		Name:   "Template",
+		Format: `{{.Name}} {{clock .Remaining}} {{.Progress}}% {{join .Tags ","}}`,
+		Status: report.Status{
+			Name:      string(config.Work),
+			Tags:      []string{"writing", "novel"},
+			Remaining: 90 * time.Second,
+			Progress:  94,
+		},
+		Expected: "Work session 01:30 94% writing,novel",
+	},
+	{
+		Name:   "Invalid template",
+		Format: "{{.Name",
+		Err:    errInvalidStatusFormat,
+	},
+}
+
+func TestStatusFormatter(t *testing.T) {
+	for _, tc := range statusFormatTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			render, err := statusFormatter(tc.Format)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error to be: %v, but got: %v", tc.Err, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := render(&tc.Status)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.Expected {
+				t.Errorf("expected status to be: %q, but got: %q", tc.Expected, got)
+			}
+		})
+	}
+}
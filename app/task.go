@@ -0,0 +1,290 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+	"github.com/ayoisaiah/focus/internal/ui"
+	"github.com/ayoisaiah/focus/store"
+	"github.com/ayoisaiah/focus/timer"
+)
+
+// noTask is the value of --task that starts the timer without a task.
+const noTask = "none"
+
+type (
+	// taskTotal is the work recorded against a task.
+	taskTotal struct {
+		Duration time.Duration `json:"duration"`
+		// Completed is the number of completed work sessions
+		Completed int `json:"completed"`
+	}
+
+	// taskJSON is the representation of a task in `focus task list --json`.
+	taskJSON struct {
+		*models.Task
+		Status models.TaskStatus `json:"status"`
+		taskTotal
+	}
+)
+
+// taskTotals sums up the work sessions recorded against each task.
+func taskTotals(sessions []*models.Session) map[int]taskTotal {
+	totals := make(map[int]taskTotal)
+
+	for _, sess := range sessions {
+		if sess.TaskID == 0 || sess.IsBreak() {
+			continue
+		}
+
+		total := totals[sess.TaskID]
+
+		for _, v := range sess.Timeline {
+			total.Duration += v.EndTime.Sub(v.StartTime)
+		}
+
+		if sess.Completed {
+			total.Completed++
+		}
+
+		totals[sess.TaskID] = total
+	}
+
+	return totals
+}
+
+// formatTaskTime formats a duration in hours and minutes (e.g. 2h 15m).
+func formatTaskTime(d time.Duration) string {
+	hrs, mins := timeutil.MinsToHoursAndMins(int(d.Minutes()))
+
+	if hrs == 0 {
+		return fmt.Sprintf("%dm", mins)
+	}
+
+	return fmt.Sprintf("%dh %dm", hrs, mins)
+}
+
+// openTasks returns the tasks that are neither done nor archived.
+func openTasks(tasks []*models.Task) []*models.Task {
+	var result []*models.Task
+
+	for _, v := range tasks {
+		if v.Status() == models.TaskOpen {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// findTask returns the open task with the specified ID (optionally prefixed
+// with #) or name. Names are matched regardless of case.
+func findTask(tasks []*models.Task, arg string) (*models.Task, error) {
+	arg = strings.TrimSpace(arg)
+
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		id = 0
+	}
+
+	for _, v := range openTasks(tasks) {
+		if v.ID == id || strings.EqualFold(v.Name, arg) {
+			return v, nil
+		}
+	}
+
+	return nil, errTaskNotFound
+}
+
+// parseTaskIDs parses task IDs (optionally prefixed with #) from the
+// command-line arguments.
+func parseTaskIDs(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, errNoTaskIDs
+	}
+
+	ids := make([]int, len(args))
+
+	for i, arg := range args {
+		id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || id < 1 {
+			return nil, errNoTaskIDs
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// printTasksTable prints a table of tasks along with the work recorded
+// against each one.
+func printTasksTable(
+	w io.Writer,
+	tasks []*models.Task,
+	totals map[int]taskTotal,
+) {
+	tableBody := [][]string{
+		{"#", "TASK", "TAGS", "POMODOROS", "TIME", "STATUS"},
+	}
+
+	for _, v := range tasks {
+		total := totals[v.ID]
+
+		pomodoros := strconv.Itoa(total.Completed)
+		if v.Estimate > 0 {
+			pomodoros += "/" + strconv.Itoa(v.Estimate)
+
+			if total.Completed > v.Estimate {
+				pomodoros = ui.Red(pomodoros)
+			}
+		}
+
+		status := ui.Green(string(models.TaskOpen))
+
+		switch v.Status() {
+		case models.TaskDone:
+			status = ui.Magenta(string(models.TaskDone))
+		case models.TaskArchived:
+			status = string(models.TaskArchived)
+		case models.TaskOpen:
+		}
+
+		tableBody = append(tableBody, []string{
+			strconv.Itoa(v.ID),
+			v.Name,
+			strings.Join(v.Tags, " · "),
+			pomodoros,
+			formatTaskTime(total.Duration),
+			status,
+		})
+	}
+
+	ui.PrintTable(tableBody, w)
+}
+
+// listTasks prints out a table of tasks, or a JSON array of the tasks if
+// asJSON is set. Archived tasks are only included if all is set.
+func listTasks(
+	tasks []*models.Task,
+	totals map[int]taskTotal,
+	all, asJSON bool,
+) error {
+	var result []*models.Task
+
+	for _, v := range tasks {
+		if all || v.Status() != models.TaskArchived {
+			result = append(result, v)
+		}
+	}
+
+	if asJSON {
+		out := make([]taskJSON, len(result))
+
+		for i, v := range result {
+			out[i] = taskJSON{
+				Task:      v,
+				Status:    v.Status(),
+				taskTotal: totals[v.ID],
+			}
+		}
+
+		b, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+
+		return nil
+	}
+
+	if len(result) == 0 {
+		pterm.Info.Println("No tasks found. Add one with `focus task add <name>`")
+		return nil
+	}
+
+	printTasksTable(os.Stdout, result, totals)
+
+	return nil
+}
+
+// setTaskStatus marks the tasks with the specified IDs as done or archived.
+func setTaskStatus(db store.DB, ids []int, status models.TaskStatus) error {
+	tasks := make([]*models.Task, len(ids))
+
+	for i, id := range ids {
+		task, err := db.GetTask(id)
+		if err != nil {
+			return fmt.Errorf("task #%d: %w", id, err)
+		}
+
+		tasks[i] = task
+	}
+
+	now := time.Now()
+
+	err := db.BatchUpdate(func(b store.Batch) error {
+		for _, v := range tasks {
+			if status == models.TaskDone {
+				v.DoneAt = now
+			} else {
+				v.ArchivedAt = now
+			}
+
+			err := b.PutTask(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range tasks {
+		pterm.Success.Printfln("%s marked as %s", timer.TaskLabel(v), status)
+	}
+
+	return nil
+}
+
+// sessionTask returns the task that the timer should work on. It is the task
+// specified through --task if any. Otherwise, the user is prompted to pick one
+// of the open tasks unless prompt is false.
+func sessionTask(
+	db store.DB,
+	arg string,
+	prompt bool,
+) (*models.Task, error) {
+	if arg == noTask || (arg == "" && !prompt) {
+		return nil, nil
+	}
+
+	tasks, err := db.GetTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	if arg != "" {
+		return findTask(tasks, arg)
+	}
+
+	open := openTasks(tasks)
+	if len(open) == 0 {
+		return nil, nil
+	}
+
+	return timer.SelectTask(open)
+}
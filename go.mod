@@ -12,7 +12,7 @@ require (
 	github.com/pterm/pterm v0.12.80
 	github.com/urfave/cli/v2 v2.27.6
 	go.etcd.io/bbolt v1.4.0
-	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/sys v0.31.0
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
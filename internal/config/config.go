@@ -9,19 +9,31 @@ import (
 
 	"github.com/adrg/xdg"
 	"github.com/pterm/pterm"
+	"github.com/spf13/viper"
 )
 
 const Version = "v1.4.2"
 
+const (
+	configDBBackend  = "db_backend"
+	defaultDBBackend = "bbolt"
+)
+
 var (
 	configDir      = "focus"
 	configFileName = "config.yml"
 	dbFileName     = "focus.db"
 	statusFileName = "status.json"
+	lockFileName   = "focus.lock"
+	socketFileName = "focus.sock"
 	logFileName    = "focus.log"
+	backupDirName  = "backups"
 	dbFilePath     string
+	backupDirPath  string
 	configFilePath string
 	statusFilePath string
+	lockFilePath   string
+	socketFilePath string
 	logFilePath    string
 )
 
@@ -39,10 +51,42 @@ func DBFilePath() string {
 	return dbFilePath
 }
 
+// DBBackend returns the name of the storage backend set through the
+// `db_backend` key in the config file. It doesn't prompt for the initial
+// configuration if the config file does not exist yet.
+func DBBackend() string {
+	v := viper.New()
+	v.SetConfigFile(configFilePath)
+
+	_ = v.ReadInConfig()
+
+	if backend := strings.TrimSpace(v.GetString(configDBBackend)); backend != "" {
+		return backend
+	}
+
+	return defaultDBBackend
+}
+
+// BackupDirPath returns the directory where database backups are saved.
+func BackupDirPath() string {
+	return backupDirPath
+}
+
 func StatusFilePath() string {
 	return statusFilePath
 }
 
+// LockFilePath returns the file that is locked while a timer is active.
+func LockFilePath() string {
+	return lockFilePath
+}
+
+// SocketFilePath returns the Unix socket on which the active timer accepts
+// commands.
+func SocketFilePath() string {
+	return socketFilePath
+}
+
 func LogFilePath() string {
 	return logFilePath
 }
@@ -53,6 +97,8 @@ func InitializePaths() {
 		configFileName = fmt.Sprintf("config_%s.yml", focusEnv)
 		dbFileName = fmt.Sprintf("focus_%s.db", focusEnv)
 		statusFileName = fmt.Sprintf("status_%s.json", focusEnv)
+		lockFileName = fmt.Sprintf("focus_%s.lock", focusEnv)
+		socketFileName = fmt.Sprintf("focus_%s.sock", focusEnv)
 		logFileName = fmt.Sprintf("focus_%s.log", focusEnv)
 	}
 
@@ -76,5 +122,11 @@ func InitializePaths() {
 
 	statusFilePath = filepath.Join(dataDir, statusFileName)
 
+	lockFilePath = filepath.Join(dataDir, lockFileName)
+
+	socketFilePath = filepath.Join(xdg.RuntimeDir, configDir, socketFileName)
+
+	backupDirPath = filepath.Join(dataDir, backupDirName)
+
 	logFilePath = filepath.Join(dataDir, "log", logFileName)
 }
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/viper"
+)
+
+type (
+	// SessionType is a kind of session that can appear in a cycle such as a
+	// work session, a short break, or a user-defined type like "stretch".
+	SessionType struct {
+		// Kind determines whether sessions of this type are recorded as work
+		// sessions, short breaks, or long breaks
+		Kind    SessType `json:"kind"`
+		Message string   `json:"message"`
+		Color   string   `json:"color"`
+		// Duration is the length of a session of this type in a cycle that
+		// doesn't specify one. User-defined types without a duration use that
+		// of their kind
+		Duration time.Duration `json:"duration"`
+	}
+
+	// SessionTypes maps the name of a user-defined session type to its
+	// settings.
+	SessionTypes map[string]SessionType
+
+	// Step is a session in a cycle.
+	Step struct {
+		// Name is the name of the session type (e.g. work or break)
+		Name string `json:"name"`
+		SessionType
+	}
+
+	// Cycle is the sequence of sessions that the timer repeats. It always
+	// starts with a work session.
+	Cycle []Step
+)
+
+// The built-in session types. They are configured through the duration,
+// message and colour settings for each session.
+const (
+	sessionTypeWork  = "work"
+	sessionTypeBreak = "break"
+	sessionTypeShort = "short"
+	sessionTypeLong  = "long"
+)
+
+// sessionKinds maps the names that can be used for the kind of a session type
+// to the corresponding session.
+var sessionKinds = map[string]SessType{
+	sessionTypeWork:  Work,
+	sessionTypeBreak: ShortBreak,
+	sessionTypeShort: ShortBreak,
+	sessionTypeLong:  LongBreak,
+}
+
+// BuiltinSessionType reports whether name is one of the built-in session
+// types.
+func BuiltinSessionType(name string) bool {
+	_, ok := sessionKinds[name]
+
+	return ok
+}
+
+// ratioProfile matches profiles such as 52/17 which alternate between a work
+// session and a break of the specified number of minutes.
+var ratioProfile = regexp.MustCompile(`^(\d+)/(\d+)$`)
+
+var (
+	errUnknownSessionType = errors.New("unknown session type")
+	errInvalidStep        = errors.New(
+		"expected a session type optionally followed by a duration (e.g. work 50)",
+	)
+	errCycleStart = errors.New("the cycle must start with a work session")
+	errNoProfile  = errors.New("the profile is not defined")
+)
+
+// WorkSessions returns the number of work sessions in the cycle.
+func (c Cycle) WorkSessions() int {
+	var n int
+
+	for _, v := range c {
+		if v.Kind == Work {
+			n++
+		}
+	}
+
+	return n
+}
+
+// WorkStep returns the index of the nth work session in the cycle, or zero if
+// there aren't up to n work sessions.
+func (c Cycle) WorkStep(n int) int {
+	for i, v := range c {
+		if v.Kind != Work {
+			continue
+		}
+
+		n--
+		if n == 0 {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// WorkNumber returns the position of the work session at the specified index
+// among the work sessions in the cycle (starting from 1).
+func (c Cycle) WorkNumber(index int) int {
+	var n int
+
+	for _, v := range c[:index+1] {
+		if v.Kind == Work {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Step returns the built-in step for the specified session.
+func (c *TimerConfig) Step(kind SessType) Step {
+	step := Step{
+		SessionType: SessionType{
+			Kind:     kind,
+			Message:  c.Message[kind],
+			Duration: c.Duration[kind],
+		},
+	}
+
+	switch kind {
+	case Work:
+		step.Name = sessionTypeWork
+		step.Color = c.WorkColor
+	case ShortBreak:
+		step.Name = sessionTypeBreak
+		step.Color = c.ShortBreakColor
+	case LongBreak:
+		step.Name = sessionTypeLong
+		step.Color = c.LongBreakColor
+	}
+
+	return step
+}
+
+// defaultCycle returns the classic Pomodoro cycle where a long break follows
+// every LongBreakInterval work sessions, and short breaks follow the rest.
+func (c *TimerConfig) defaultCycle() Cycle {
+	var cycle Cycle
+
+	for range c.LongBreakInterval - 1 {
+		cycle = append(cycle, c.Step(Work), c.Step(ShortBreak))
+	}
+
+	return append(cycle, c.Step(Work), c.Step(LongBreak))
+}
+
+// sessionType returns the session type with the specified name. The settings
+// that a user-defined type leaves out are taken from its kind.
+func (c *TimerConfig) sessionType(name string) (SessionType, error) {
+	if kind, ok := sessionKinds[name]; ok {
+		return c.Step(kind).SessionType, nil
+	}
+
+	if t, ok := c.SessionTypes[name]; ok {
+		// unset values are resolved here rather than when the config file
+		// is read so that they reflect the command-line arguments
+		kind := c.Step(t.Kind)
+
+		if t.Duration == 0 {
+			t.Duration = kind.Duration
+		}
+
+		if t.Message == "" {
+			t.Message = kind.Message
+		}
+
+		if t.Color == "" {
+			t.Color = kind.Color
+		}
+
+		return t, nil
+	}
+
+	return SessionType{}, fmt.Errorf("%w: %s", errUnknownSessionType, name)
+}
+
+// parseCycle parses a cycle where each step is the name of a session type
+// optionally followed by its duration (e.g. work 50, break 10m).
+func (c *TimerConfig) parseCycle(steps []string) (Cycle, error) {
+	var cycle Cycle
+
+	for _, v := range steps {
+		fields := strings.Fields(v)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("%w: %q", errInvalidStep, v)
+		}
+
+		name := strings.ToLower(fields[0])
+
+		t, err := c.sessionType(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) == 2 {
+			t.Duration, err = parseDuration(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", errInvalidStep, v)
+			}
+		}
+
+		cycle = append(cycle, Step{Name: name, SessionType: t})
+	}
+
+	if len(cycle) == 0 || cycle[0].Kind != Work {
+		return nil, errCycleStart
+	}
+
+	return cycle, nil
+}
+
+// profileSteps returns the steps of the specified profile from the profiles
+// in the config file. A profile can be a list of steps, a comma-separated
+// string of steps, or a ratio of work and break minutes such as 52/17 which
+// doesn't need to be defined.
+func profileSteps(profile string) ([]string, error) {
+	v := viper.Get(configProfiles + "." + profile)
+
+	switch v := v.(type) {
+	case string:
+		profile = v
+	case []any:
+		steps := make([]string, len(v))
+		for i := range v {
+			steps[i] = fmt.Sprint(v[i])
+		}
+
+		return steps, nil
+	}
+
+	if m := ratioProfile.FindStringSubmatch(profile); m != nil {
+		return []string{
+			sessionTypeWork + " " + m[1],
+			sessionTypeBreak + " " + m[2],
+		}, nil
+	}
+
+	if v == nil {
+		return nil, errNoProfile
+	}
+
+	return strings.Split(profile, ","), nil
+}
+
+// parseDuration parses a duration in minutes (e.g. 50) or in the format
+// accepted by time.ParseDuration (e.g. 1h30m).
+func parseDuration(s string) (time.Duration, error) {
+	if _, err := strconv.Atoi(s); err == nil {
+		s += "m"
+	}
+
+	d, err := time.ParseDuration(s)
+	if err == nil && d <= 0 {
+		err = errInvalidStep
+	}
+
+	return d, err
+}
+
+// sessionTypesFromFile retrieves the user-defined session types from the
+// config file. The kind of a session type defaults to a short break, and the
+// settings that are not set are left empty so that sessionType can use those
+// of its kind. A session type that shadows a built-in type or has an unknown
+// kind is ignored with a warning.
+func sessionTypesFromFile() SessionTypes {
+	types := make(SessionTypes)
+
+	for name := range viper.GetStringMap(configSessionTypes) {
+		key := configSessionTypes + "." + name
+
+		if _, ok := sessionKinds[name]; ok {
+			pterm.Warning.Printfln(
+				"config error: %s is a built-in session type, ignoring %s",
+				name,
+				key,
+			)
+
+			continue
+		}
+
+		kind := ShortBreak
+
+		if s := viper.GetString(key + ".kind"); s != "" {
+			var ok bool
+
+			kind, ok = sessionKinds[strings.ToLower(s)]
+			if !ok {
+				pterm.Warning.Printfln(
+					"config error: invalid %s.kind value, ignoring %s",
+					key,
+					key,
+				)
+
+				continue
+			}
+		}
+
+		t := SessionType{
+			Kind:    kind,
+			Message: viper.GetString(key + ".message"),
+			Color:   viper.GetString(key + ".color"),
+		}
+
+		if s := viper.GetString(key + ".duration"); s != "" {
+			d, err := parseDuration(s)
+			if err != nil {
+				pterm.Warning.Printfln(
+					"config error: invalid %s.duration value, using the %s duration",
+					key,
+					kind,
+				)
+			} else {
+				t.Duration = d
+			}
+		}
+
+		types[name] = t
+	}
+
+	return types
+}
+
+// setCycle sets the cycle of the selected profile, or the default cycle if no
+// profile is selected or the profile is invalid.
+func setCycle() {
+	timerCfg.Cycle = timerCfg.defaultCycle()
+
+	if timerCfg.Profile == "" {
+		return
+	}
+
+	steps, err := profileSteps(timerCfg.Profile)
+	if err == nil {
+		var cycle Cycle
+
+		cycle, err = timerCfg.parseCycle(steps)
+		if err == nil {
+			timerCfg.Cycle = cycle
+			return
+		}
+	}
+
+	pterm.Warning.Printfln(
+		"config error: invalid profile %s (%v), using the default cycle",
+		timerCfg.Profile,
+		err,
+	)
+}
@@ -2,6 +2,12 @@ package config
 
 import "github.com/ayoisaiah/focus/internal/apperr"
 
-var errSessionOverlap = &apperr.Error{
-	Message: "new sessions cannot overlap with existing ones",
-}
+var (
+	errSessionOverlap = &apperr.Error{
+		Message: "new sessions cannot overlap with existing ones",
+	}
+
+	errInvalidTagQuery = &apperr.Error{
+		Message: "invalid tag expression: join tags that must all be present with +, separate alternatives with a comma, and prefix excluded tags with !",
+	}
+)
@@ -18,7 +18,7 @@ import (
 type FilterConfig struct {
 	StartTime time.Time
 	EndTime   time.Time
-	Tags      []string
+	Tags      TagQuery
 }
 
 var (
@@ -69,16 +69,25 @@ func getTimeRange(period timeutil.Period) (start, end time.Time) {
 func setFilterConfig(ctx *cli.Context) (*FilterConfig, error) {
 	filterCfg := &FilterConfig{}
 
-	if (ctx.String("tag")) != "" {
-		filterCfg.Tags = strings.Split(ctx.String("tag"), ",")
+	tags, err := ParseTagQuery(ctx.String("tag"))
+	if err != nil {
+		return nil, err
 	}
 
+	filterCfg.Tags = tags
+
 	period := timeutil.Period(strings.TrimSpace(ctx.String("period")))
 
 	if period != "" && !slices.Contains(timeutil.PeriodCollection, period) {
 		return nil, errInvalidPeriod
 	}
 
+	// default to the last 7 days when no time constraints are provided.
+	// Commands that change sessions require a time range instead
+	if period == "" && ctx.String("start") == "" && ctx.String("end") == "" {
+		period = timeutil.Period7Days
+	}
+
 	if period != "" {
 		filterCfg.StartTime, filterCfg.EndTime = getTimeRange(period)
 
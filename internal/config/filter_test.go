@@ -2,8 +2,7 @@ package config
 
 import (
 	"flag"
-	"slices"
-	"strings"
+	"reflect"
 	"testing"
 
 	"github.com/urfave/cli/v2"
@@ -24,6 +23,14 @@ var filterTestCases = []FilterTest{
 			"period": "7days",
 		},
 	},
+	{
+		Name: "Provide a tag expression",
+		Args: []string{"-period 7days", "-tag work+urgent,!meeting"},
+		Flags: map[string]string{
+			"period": "7days",
+			"tag":    "work+urgent,!meeting",
+		},
+	},
 }
 
 func TestFilter(t *testing.T) {
@@ -43,13 +50,12 @@ func TestFilter(t *testing.T) {
 
 			cfg := Filter(ctx)
 
-			var expectedTags []string
-
-			if _, ok := tc.Flags["tag"]; ok {
-				expectedTags = strings.Split(tc.Flags["tag"], ",")
+			expectedTags, err := ParseTagQuery(tc.Flags["tag"])
+			if err != nil {
+				t.Fatal(err)
 			}
 
-			if !slices.Equal(cfg.Tags, expectedTags) {
+			if !reflect.DeepEqual(cfg.Tags, expectedTags) {
 				t.Errorf(
 					"expected tags to be: %v, but got: %v",
 					expectedTags,
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	tagQueryOr  = ","
+	tagQueryAnd = "+"
+	tagQueryNot = "!"
+)
+
+type (
+	// TagTerm matches sessions that have the specified tag, or sessions that
+	// don't have it if Negate is set.
+	TagTerm struct {
+		Tag    string
+		Negate bool
+	}
+
+	// TagQuery is a tag expression in disjunctive normal form. A session
+	// matches the query if it matches all the terms in any of the groups.
+	// The query `work+urgent,personal+!meeting` matches sessions tagged with
+	// both work and urgent, or tagged with personal but not meeting.
+	TagQuery [][]TagTerm
+)
+
+// ParseTagQuery parses a tag expression where `,` separates alternatives,
+// `+` joins tags that must all be present, and `!` excludes a tag.
+func ParseTagQuery(s string) (TagQuery, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var q TagQuery
+
+	for _, group := range strings.Split(s, tagQueryOr) {
+		var terms []TagTerm
+
+		for _, v := range strings.Split(group, tagQueryAnd) {
+			v = strings.TrimSpace(v)
+
+			term := TagTerm{
+				Tag:    strings.TrimSpace(strings.TrimPrefix(v, tagQueryNot)),
+				Negate: strings.HasPrefix(v, tagQueryNot),
+			}
+
+			if term.Tag == "" {
+				return nil, errInvalidTagQuery
+			}
+
+			terms = append(terms, term)
+		}
+
+		q = append(q, terms)
+	}
+
+	return q, nil
+}
+
+// Match reports whether a session with the provided tags satisfies the query.
+// An empty query matches all sessions.
+func (q TagQuery) Match(tags []string) bool {
+	return q.MatchFunc(func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+}
+
+// MatchFunc is like Match but reports whether a session has a tag by calling
+// has. It allows the query to be evaluated without decoding the session.
+func (q TagQuery) MatchFunc(has func(tag string) bool) bool {
+	if len(q) == 0 {
+		return true
+	}
+
+	for _, group := range q {
+		matched := true
+
+		for _, term := range group {
+			if has(term.Tag) == term.Negate {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the tag expression that represents the query.
+func (q TagQuery) String() string {
+	groups := make([]string, len(q))
+
+	for i, group := range q {
+		terms := make([]string, len(group))
+
+		for j, term := range group {
+			terms[j] = term.Tag
+			if term.Negate {
+				terms[j] = tagQueryNot + term.Tag
+			}
+		}
+
+		groups[i] = strings.Join(terms, tagQueryAnd)
+	}
+
+	return strings.Join(groups, tagQueryOr)
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+type TagQueryTest struct {
+	Name    string
+	Query   string
+	Tags    []string
+	Matches bool
+	Err     error
+}
+
+var tagQueryTestCases = []TagQueryTest{
+	{
+		Name:    "Empty query matches untagged sessions",
+		Query:   "",
+		Matches: true,
+	},
+	{
+		Name:    "Any of the tags",
+		Query:   "work,personal",
+		Tags:    []string{"personal"},
+		Matches: true,
+	},
+	{
+		Name:    "All of the tags",
+		Query:   "work+urgent",
+		Tags:    []string{"work", "urgent"},
+		Matches: true,
+	},
+	{
+		Name:  "Missing one of the required tags",
+		Query: "work+urgent",
+		Tags:  []string{"work"},
+	},
+	{
+		Name:  "Excluded tag",
+		Query: "!meeting",
+		Tags:  []string{"work", "meeting"},
+	},
+	{
+		Name:    "Excluded tag matches untagged sessions",
+		Query:   "!meeting",
+		Matches: true,
+	},
+	{
+		Name:    "Combined expression",
+		Query:   "work+urgent, personal+!meeting",
+		Tags:    []string{"personal", "reading"},
+		Matches: true,
+	},
+	{
+		Name:  "Empty term",
+		Query: "work+",
+		Err:   errInvalidTagQuery,
+	},
+	{
+		Name:  "Negation without a tag",
+		Query: "work,!",
+		Err:   errInvalidTagQuery,
+	},
+}
+
+func TestTagQuery(t *testing.T) {
+	for _, tc := range tagQueryTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			q, err := ParseTagQuery(tc.Query)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error to be: %v, but got: %v", tc.Err, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if q.Match(tc.Tags) != tc.Matches {
+				t.Errorf(
+					"expected query %q to match %v: %t",
+					tc.Query,
+					tc.Tags,
+					tc.Matches,
+				)
+			}
+		})
+	}
+}
@@ -29,31 +29,55 @@ type (
 	// Duration maps a session to time duration value.
 	Duration map[SessType]time.Duration
 
+	// HookEvent identifies a point in the lifecycle of a session where a hook
+	// can be executed.
+	HookEvent string
+
+	// Hooks maps a session lifecycle event to a command.
+	Hooks map[HookEvent]string
+
+	// DailyGoal is the amount of work to complete each day, expressed as a
+	// number of completed work sessions or as the time spent working. At
+	// most one of the fields is set.
+	DailyGoal struct {
+		Sessions int           `json:"sessions,omitempty"`
+		Duration time.Duration `json:"duration,omitempty"`
+	}
+
 	// TimerConfig represents the program configuration derived from the config file
 	// and command-line arguments.
 	TimerConfig struct {
-		StartTime           time.Time `json:"-"`
-		Since               string    `json:"-"`
-		Duration            Duration  `json:"duration"`
-		Message             Message   `json:"message"`
-		AmbientSound        string    `json:"sound"`
-		BreakSound          string    `json:"break_sound"`
-		WorkSound           string    `json:"work_sound"`
-		PathToConfig        string    `json:"path_to_config"`
-		PathToDB            string    `json:"path_to_db"`
-		SessionCmd          string    `json:"session_cmd"`
-		WorkColor           string    `json:"work_color"`
-		ShortBreakColor     string    `json:"short_break_color"`
-		LongBreakColor      string    `json:"long_break_color"`
-		Tags                []string  `json:"tags"`
-		LongBreakInterval   int       `json:"long_break_interval"`
-		Notify              bool      `json:"notify"`
-		DarkTheme           bool      `json:"dark_theme"`
-		TwentyFourHourClock bool      `json:"twenty_four_hour_clock"`
-		PlaySoundOnBreak    bool      `json:"sound_on_break"`
-		AutoStartBreak      bool      `json:"auto_start_break"`
-		AutoStartWork       bool      `json:"auto_start_work"`
-		Strict              bool      `json:"strict"`
+		StartTime           time.Time     `json:"-"`
+		Since               string        `json:"-"`
+		Duration            Duration      `json:"duration"`
+		Message             Message       `json:"message"`
+		AmbientSound        string        `json:"sound"`
+		BreakSound          string        `json:"break_sound"`
+		WorkSound           string        `json:"work_sound"`
+		PathToConfig        string        `json:"path_to_config"`
+		PathToDB            string        `json:"path_to_db"`
+		SessionCmd          string        `json:"session_cmd"`
+		WorkColor           string        `json:"work_color"`
+		ShortBreakColor     string        `json:"short_break_color"`
+		LongBreakColor      string        `json:"long_break_color"`
+		Hooks               Hooks         `json:"hooks"`
+		SessionTypes        SessionTypes  `json:"session_types"`
+		Profile             string        `json:"profile"`
+		Cycle               Cycle         `json:"cycle"`
+		DailyGoal           DailyGoal     `json:"daily_goal"`
+		Tags                []string      `json:"tags"`
+		HookTimeout         time.Duration `json:"hook_timeout"`
+		LongBreakInterval   int           `json:"long_break_interval"`
+		BackupCount         int           `json:"backup_count"`
+		MaxSessions         int           `json:"max_sessions"`
+		Notify              bool          `json:"notify"`
+		DarkTheme           bool          `json:"dark_theme"`
+		TwentyFourHourClock bool          `json:"twenty_four_hour_clock"`
+		PlaySoundOnBreak    bool          `json:"sound_on_break"`
+		AutoStartBreak      bool          `json:"auto_start_break"`
+		AutoStartWork       bool          `json:"auto_start_work"`
+		Strict              bool          `json:"strict"`
+		SessionNotes        bool          `json:"session_notes"`
 	}
 )
 
@@ -63,6 +87,27 @@ const (
 	LongBreak  SessType = "Long break"
 )
 
+const (
+	HookWorkStart  HookEvent = "on_work_start"
+	HookWorkEnd    HookEvent = "on_work_end"
+	HookBreakStart HookEvent = "on_break_start"
+	HookBreakEnd   HookEvent = "on_break_end"
+	HookPause      HookEvent = "on_pause"
+	HookResume     HookEvent = "on_resume"
+	HookAbandon    HookEvent = "on_abandon"
+)
+
+// HookEvents lists all the supported hook events.
+var HookEvents = []HookEvent{
+	HookWorkStart,
+	HookWorkEnd,
+	HookBreakStart,
+	HookBreakEnd,
+	HookPause,
+	HookResume,
+	HookAbandon,
+}
+
 const ascii = `
 ███████╗ ██████╗  ██████╗██╗   ██╗███████╗
 ██╔════╝██╔═══██╗██╔════╝██║   ██║██╔════╝
@@ -76,8 +121,11 @@ const (
 	defaultShortBreakMins    = 5
 	defaultLongBreakMins     = 15
 	defaultLongBreakInterval = 4
+	defaultBackupCount       = 7
 )
 
+const defaultHookTimeout = 30 * time.Second
+
 const (
 	defaultWorkColor       = "#B0DB43"
 	defaultShortBreakColor = "#12EAEA"
@@ -115,6 +163,15 @@ const (
 	configWorkColor           = "work_color"
 	configShortBreakColor     = "short_break_color"
 	configLongBreakColor      = "long_break_color"
+	configHooks               = "hooks"
+	configHookTimeout         = "hook_timeout"
+	configBackupCount         = "backup_count"
+	configMaxSessions         = "max_sessions"
+	configDailyGoal           = "daily_goal"
+	configSessionNotes        = "session_notes"
+	configSessionTypes        = "session_types"
+	configProfiles            = "profiles"
+	configProfile             = "profile"
 )
 
 var once sync.Once
@@ -134,8 +191,46 @@ var (
 	errInitFailed = errors.New(
 		"Unable to initialise Focus settings from the configuration file",
 	)
+	errInvalidDailyGoal = errors.New(
+		"Expected a number of sessions or a duration such as 4h",
+	)
 )
 
+// IsSet reports whether a daily goal is configured.
+func (g DailyGoal) IsSet() bool {
+	return g.Sessions > 0 || g.Duration > 0
+}
+
+// parseDailyGoal parses a daily goal which is either a number of work sessions
+// (e.g. 8) or the time to spend working (e.g. 240m or 4h).
+func parseDailyGoal(s string) (DailyGoal, error) {
+	var goal DailyGoal
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return goal, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return goal, errInvalidDailyGoal
+		}
+
+		goal.Sessions = n
+
+		return goal, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Minute {
+		return goal, errInvalidDailyGoal
+	}
+
+	goal.Duration = d
+
+	return goal, nil
+}
+
 func parseTime(s, key string, d int) time.Duration {
 	_, err := strconv.Atoi(s)
 	if err == nil {
@@ -326,6 +421,14 @@ func overrideConfigFromArgs(ctx *cli.Context) {
 		timerCfg.LongBreakInterval = int(ctx.Uint("long-break-interval"))
 	}
 
+	if ctx.String("profile") != "" {
+		timerCfg.Profile = ctx.String("profile")
+	}
+
+	if ctx.Uint("max-sessions") > 0 {
+		timerCfg.MaxSessions = int(ctx.Uint("max-sessions"))
+	}
+
 	timerCfg.Since = ctx.String("since")
 
 	timerCfg.StartTime = time.Now()
@@ -397,6 +500,7 @@ func updateConfigFromFile() {
 	timerCfg.AutoStartBreak = viper.GetBool(configAutoStartBreak)
 	timerCfg.AutoStartWork = viper.GetBool(configAutoStartWork)
 	timerCfg.Strict = viper.GetBool(configStrict)
+	timerCfg.SessionNotes = viper.GetBool(configSessionNotes)
 	timerCfg.Notify = viper.GetBool(configNotify)
 	timerCfg.TwentyFourHourClock = viper.GetBool(configTwentyFourHourClock)
 	timerCfg.PlaySoundOnBreak = viper.GetBool(configSoundOnBreak)
@@ -404,10 +508,65 @@ func updateConfigFromFile() {
 	timerCfg.SessionCmd = viper.GetString(configSessionCmd)
 	timerCfg.BreakSound = viper.GetString(configBreakSound)
 	timerCfg.WorkSound = viper.GetString(configWorkSound)
+	timerCfg.Profile = viper.GetString(configProfile)
 	timerCfg.WorkColor = viper.GetString(configWorkColor)
 	timerCfg.ShortBreakColor = viper.GetString(configShortBreakColor)
 	timerCfg.LongBreakColor = viper.GetString(configLongBreakColor)
 
+	hooks := viper.GetStringMapString(configHooks)
+	if len(hooks) > 0 {
+		timerCfg.Hooks = make(Hooks)
+
+		for _, event := range HookEvents {
+			if cmd := strings.TrimSpace(hooks[string(event)]); cmd != "" {
+				timerCfg.Hooks[event] = cmd
+			}
+		}
+	}
+
+	timerCfg.BackupCount = defaultBackupCount
+
+	if viper.IsSet(configBackupCount) {
+		backupCount := viper.GetInt(configBackupCount)
+		if backupCount >= 0 {
+			timerCfg.BackupCount = backupCount
+		} else {
+			warnOnInvalidConfig(configBackupCount, defaultBackupCount)
+		}
+	}
+
+	maxSessions := viper.GetInt(configMaxSessions)
+	if maxSessions >= 0 {
+		timerCfg.MaxSessions = maxSessions
+	} else {
+		warnOnInvalidConfig(configMaxSessions, 0)
+	}
+
+	dailyGoal, err := parseDailyGoal(viper.GetString(configDailyGoal))
+	if err != nil {
+		pterm.Warning.Printfln(
+			"config error: invalid %s value, ignoring it",
+			configDailyGoal,
+		)
+	}
+
+	timerCfg.DailyGoal = dailyGoal
+
+	timerCfg.HookTimeout = defaultHookTimeout
+
+	if viper.IsSet(configHookTimeout) {
+		hookTimeout := viper.GetDuration(configHookTimeout)
+		if hookTimeout > 0 {
+			timerCfg.HookTimeout = hookTimeout
+		} else {
+			pterm.Warning.Printfln(
+				"config error: invalid %s value, using default (%s)",
+				configHookTimeout,
+				defaultHookTimeout,
+			)
+		}
+	}
+
 	if viper.IsSet(configDarkTheme) {
 		timerCfg.DarkTheme = viper.GetBool(configDarkTheme)
 	} else {
@@ -421,6 +580,8 @@ func updateConfigFromFile() {
 	timerCfg.Message[LongBreak] = viper.GetString(
 		configLongBreakMessage,
 	)
+
+	timerCfg.SessionTypes = sessionTypesFromFile()
 }
 
 // setTimerConfig overrides the default configuaration with user-defined
@@ -434,6 +595,9 @@ func setTimerConfig(ctx *cli.Context) {
 
 	// set from command-line arguments
 	overrideConfigFromArgs(ctx)
+
+	// the cycle depends on the session settings from both
+	setCycle()
 }
 
 // createTimerConfig saves the user's configuration to disk
@@ -473,10 +637,13 @@ func timerDefaults() {
 	viper.SetDefault(configSoundOnBreak, false)
 	viper.SetDefault(configAmbientSound, "")
 	viper.SetDefault(configSessionCmd, "")
+	viper.SetDefault(configHookTimeout, defaultHookTimeout)
+	viper.SetDefault(configBackupCount, defaultBackupCount)
 	viper.SetDefault(configDarkTheme, true)
 	viper.SetDefault(configBreakSound, "bell")
 	viper.SetDefault(configWorkSound, "loud_bell")
 	viper.SetDefault(configStrict, false)
+	viper.SetDefault(configSessionNotes, false)
 	viper.SetDefault(configWorkColor, defaultWorkColor)
 	viper.SetDefault(configShortBreakColor, defaultShortBreakColor)
 	viper.SetDefault(configLongBreakColor, defaultLongBreakColor)
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -34,6 +35,8 @@ var timerTestCases = []TimerTest{
 				ShortBreak: "Take a breather",
 				LongBreak:  "Take a long break",
 			},
+			HookTimeout:         30 * time.Second,
+			BackupCount:         7,
 			LongBreakInterval:   4,
 			Notify:              true,
 			DarkTheme:           true,
@@ -60,6 +63,8 @@ var timerTestCases = []TimerTest{
 				ShortBreak: "Take a breather",
 				LongBreak:  "Take a long break",
 			},
+			HookTimeout:         30 * time.Second,
+			BackupCount:         7,
 			LongBreakInterval:   4,
 			Notify:              true,
 			DarkTheme:           true,
@@ -86,6 +91,8 @@ var timerTestCases = []TimerTest{
 				ShortBreak: "Take a breather",
 				LongBreak:  "Take a long break",
 			},
+			HookTimeout:         30 * time.Second,
+			BackupCount:         7,
 			LongBreakInterval:   5,
 			Notify:              true,
 			DarkTheme:           true,
@@ -112,6 +119,8 @@ var timerTestCases = []TimerTest{
 				ShortBreak: "Take a breather",
 				LongBreak:  "Take a long break",
 			},
+			HookTimeout:         30 * time.Second,
+			BackupCount:         7,
 			LongBreakInterval:   4,
 			Notify:              true,
 			DarkTheme:           true,
@@ -201,3 +210,153 @@ func TestTimer(t *testing.T) {
 		})
 	}
 }
+
+type DailyGoalTest struct {
+	Name     string
+	Input    string
+	Expected DailyGoal
+	Invalid  bool
+}
+
+var dailyGoalTestCases = []DailyGoalTest{
+	{
+		Name: "No goal",
+	},
+	{
+		Name:     "Number of sessions",
+		Input:    "8",
+		Expected: DailyGoal{Sessions: 8},
+	},
+	{
+		Name:     "Time in minutes",
+		Input:    "240m",
+		Expected: DailyGoal{Duration: 4 * time.Hour},
+	},
+	{
+		Name:     "Time in hours and minutes",
+		Input:    " 2h30m ",
+		Expected: DailyGoal{Duration: 150 * time.Minute},
+	},
+	{
+		Name:    "Zero sessions",
+		Input:   "0",
+		Invalid: true,
+	},
+	{
+		Name:    "Unknown unit",
+		Input:   "8 sessions",
+		Invalid: true,
+	},
+}
+
+func TestParseDailyGoal(t *testing.T) {
+	for _, tc := range dailyGoalTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			goal, err := parseDailyGoal(tc.Input)
+			if (err != nil) != tc.Invalid {
+				t.Fatalf("expected invalid to be %t, but got error: %v", tc.Invalid, err)
+			}
+
+			if goal != tc.Expected {
+				t.Errorf("expected %+v, but got: %+v", tc.Expected, goal)
+			}
+		})
+	}
+}
+
+type ParseCycleTest struct {
+	Name     string
+	Steps    []string
+	Expected []string
+	// Messages are the messages of the steps, if checked
+	Messages []string
+	Invalid  bool
+}
+
+var parseCycleTestCases = []ParseCycleTest{
+	{
+		Name:     "Built-in session types",
+		Steps:    []string{"work 50", "break 10", "work", "long 30m"},
+		Expected: []string{"work 50m0s", "break 10m0s", "work 25m0s", "long 30m0s"},
+	},
+	{
+		Name:     "User-defined session type",
+		Steps:    []string{"Work 90", "stretch"},
+		Expected: []string{"work 1h30m0s", "stretch 7m0s"},
+	},
+	{
+		Name:     "User-defined session type without a duration or message",
+		Steps:    []string{"work", "walk"},
+		Expected: []string{"work 25m0s", "walk 15m0s"},
+		Messages: []string{"Focus on your task", "Take a long break"},
+	},
+	{
+		Name:    "Cycle starts with a break",
+		Steps:   []string{"break 10", "work 50"},
+		Invalid: true,
+	},
+	{
+		Name:    "Unknown session type",
+		Steps:   []string{"work 50", "nap 20"},
+		Invalid: true,
+	},
+	{
+		Name:    "Invalid duration",
+		Steps:   []string{"work fifty"},
+		Invalid: true,
+	},
+	{
+		Name:    "Empty cycle",
+		Invalid: true,
+	},
+}
+
+func TestParseCycle(t *testing.T) {
+	cfg := &TimerConfig{
+		Duration: Duration{
+			Work:       25 * time.Minute,
+			ShortBreak: 5 * time.Minute,
+			LongBreak:  15 * time.Minute,
+		},
+		Message: Message{
+			Work:       "Focus on your task",
+			ShortBreak: "Take a breather",
+			LongBreak:  "Take a long break",
+		},
+		SessionTypes: SessionTypes{
+			"stretch": {Kind: ShortBreak, Duration: 7 * time.Minute},
+			"walk":    {Kind: LongBreak},
+		},
+	}
+
+	for _, tc := range parseCycleTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cycle, err := cfg.parseCycle(tc.Steps)
+			if (err != nil) != tc.Invalid {
+				t.Fatalf("expected invalid to be %t, but got error: %v", tc.Invalid, err)
+			}
+
+			var got []string
+			for _, v := range cycle {
+				got = append(got, v.Name+" "+v.Duration.String())
+			}
+
+			if !slices.Equal(got, tc.Expected) {
+				t.Errorf("expected %v, but got: %v", tc.Expected, got)
+			}
+
+			if tc.Messages == nil {
+				return
+			}
+
+			var messages []string
+			for _, v := range cycle {
+				messages = append(messages, v.Message)
+			}
+
+			if !slices.Equal(messages, tc.Messages) {
+				t.Errorf("expected messages %v, but got: %v", tc.Messages, messages)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/ayoisaiah/focus/internal/config"
@@ -23,4 +24,128 @@ type Session struct {
 	Timeline  []SessionTimeline `json:"timeline"`
 	Duration  time.Duration     `json:"duration"`
 	Completed bool              `json:"completed"`
+	// Type is the name of the session type in the cycle (e.g. work or
+	// stretch). It is empty for sessions recorded before session types
+	Type string `json:"type,omitempty"`
+	// Skipped reports whether a break session was ended early by the user
+	Skipped bool `json:"skipped,omitempty"`
+	// Intent is what the user set out to do in a work session
+	Intent string `json:"intent,omitempty"`
+	// Notes is a reflection on the outcome of the session
+	Notes string `json:"notes,omitempty"`
+	// TaskID identifies the task that the session was spent on, if any
+	TaskID int `json:"task_id,omitempty"`
+}
+
+// Label returns the name of the session type for sessions of a user-defined
+// type (e.g. stretch), and the name of the session otherwise (e.g. Short
+// break).
+func (s *Session) Label() string {
+	if s.Type != "" && !config.BuiltinSessionType(s.Type) {
+		return s.Type
+	}
+
+	return string(s.Name)
+}
+
+// IsBreak reports whether the session is a short or long break.
+func (s *Session) IsBreak() bool {
+	return s.Name != config.Work
+}
+
+// Timer represents the state of an interrupted work session so that it can be
+// resumed later.
+type Timer struct {
+	// PausedTime is when the timer was last paused or persisted
+	PausedTime time.Time `json:"paused_time"`
+	// SessionKey is the start time of the interrupted session
+	SessionKey time.Time `json:"session_key"`
+	WorkCycle  int       `json:"work_cycle"`
+}
+
+// TaskStatus is the state of a task.
+type TaskStatus string
+
+const (
+	TaskOpen     TaskStatus = "open"
+	TaskDone     TaskStatus = "done"
+	TaskArchived TaskStatus = "archived"
+)
+
+// Task is a named piece of work that sessions can be recorded against.
+type Task struct {
+	CreatedAt time.Time `json:"created_at"`
+	// DoneAt is when the task was marked as done
+	DoneAt time.Time `json:"done_at"`
+	// ArchivedAt is when the task was archived
+	ArchivedAt time.Time `json:"archived_at"`
+	Name       string    `json:"name"`
+	Tags       []string  `json:"tags,omitempty"`
+	ID         int       `json:"id"`
+	// Estimate is the number of work sessions that the task is expected to
+	// take
+	Estimate int `json:"estimate,omitempty"`
+}
+
+// Status reports whether the task is open, done, or archived.
+func (t *Task) Status() TaskStatus {
+	switch {
+	case !t.ArchivedAt.IsZero():
+		return TaskArchived
+	case !t.DoneAt.IsZero():
+		return TaskDone
+	}
+
+	return TaskOpen
+}
+
+// TagEstimate is the number of work sessions that the work on a tag is
+// expected to take. An estimate applies until another one is set for the same
+// tag, and an estimate of zero removes it.
+type TagEstimate struct {
+	// StartTime is when the estimate was set
+	StartTime time.Time `json:"start_time"`
+	Tag       string    `json:"tag"`
+	Estimate  int       `json:"estimate"`
+}
+
+// CurrentEstimates returns the estimates that still apply from the provided
+// estimates, which must be sorted by their start time. The latest estimate for
+// each tag is the one that applies unless it removed the estimate.
+func CurrentEstimates(estimates []*TagEstimate) []*TagEstimate {
+	latest := make(map[string]*TagEstimate)
+	for _, v := range estimates {
+		latest[v.Tag] = v
+	}
+
+	var current []*TagEstimate
+
+	for _, v := range estimates {
+		if latest[v.Tag] == v && v.Estimate > 0 {
+			current = append(current, v)
+		}
+	}
+
+	return current
+}
+
+// CountCompleted returns the number of completed work sessions on the tag of
+// the estimate that started after it was set and before the specified end
+// time. A zero end time counts the sessions that started since the estimate
+// was set.
+func (e *TagEstimate) CountCompleted(sessions []*Session, end time.Time) int {
+	var n int
+
+	for _, sess := range sessions {
+		if !sess.Completed || sess.IsBreak() ||
+			!slices.Contains(sess.Tags, e.Tag) ||
+			sess.StartTime.Before(e.StartTime) ||
+			!end.IsZero() && !sess.StartTime.Before(end) {
+			continue
+		}
+
+		n++
+	}
+
+	return n
 }
@@ -31,6 +31,11 @@ func AmbientSound(fileName string) string {
 	return filepath.Join(dir, "ambient_sound", fileName)
 }
 
+// AlertSound returns the path to the specified alert sound file.
+func AlertSound(fileName string) string {
+	return filepath.Join(dir, "alert_sound", fileName)
+}
+
 func init() {
 	_ = fs.WalkDir(
 		Files,
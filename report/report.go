@@ -9,6 +9,15 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// The states of a timer in its status.
+const (
+	StateRunning = "running"
+	StatePaused  = "paused"
+	// StateWaiting is the state of a timer that is waiting for the next
+	// session to be started
+	StateWaiting = "waiting"
+)
+
 type (
 	style struct {
 		success lipgloss.Style
@@ -16,14 +25,55 @@ type (
 	}
 	// Status represents the status of a running timer.
 	Status struct {
-		EndTime           time.Time `json:"end_date"`
-		Name              string    `json:"name"`
-		Tags              []string  `json:"tags"`
-		WorkCycle         int       `json:"work_cycle"`
-		LongBreakInterval int       `json:"long_break_interval"`
+		EndTime   time.Time `json:"end_date"`
+		StartTime time.Time `json:"start_date"`
+		// Name is the name of the session type, which is set by the user
+		// for user-defined session types
+		Name string `json:"name"`
+		// Kind is the built-in session that the session type is based on
+		Kind              string        `json:"kind"`
+		Tags              []string      `json:"tags"`
+		WorkCycle         int           `json:"work_cycle"`
+		LongBreakInterval int           `json:"long_break_interval"`
+		Duration          time.Duration `json:"duration"`
+		// Intent is what the user set out to do in a work session
+		Intent string `json:"intent,omitempty"`
+		// Task is the name of the task that the session is spent on
+		Task string `json:"task,omitempty"`
+		// PausedAt is when the session was paused. It is zero unless the
+		// session is paused
+		PausedAt time.Time `json:"paused_at"`
+		// Elapsed is the time spent in the session (excluding pauses) when
+		// the status was last refreshed
+		Elapsed time.Duration `json:"elapsed"`
+		// Remaining is the time left in the session when the status was
+		// last refreshed
+		Remaining time.Duration `json:"remaining"`
+		// State is whether the session is running, paused, or waiting to be
+		// started
+		State string `json:"state"`
+		// Progress is the percentage of the session that has elapsed
+		Progress int  `json:"progress"`
+		Paused   bool `json:"paused"`
 	}
 )
 
+// Refresh recomputes the elapsed and remaining time, and the progress of a
+// running session at the specified time. The values of a paused session are
+// kept as they were when it was paused, and those of a session that is waiting
+// to be started are left alone.
+func (s *Status) Refresh(now time.Time) {
+	if !s.Paused && s.State != StateWaiting {
+		s.Remaining = max(s.EndTime.Sub(now).Round(time.Second), 0)
+	}
+
+	s.Elapsed = max(s.Duration-s.Remaining, 0)
+
+	if s.Duration > 0 {
+		s.Progress = int(s.Elapsed * 100 / s.Duration)
+	}
+}
+
 var defaultStyle = style{
 	success: lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#78BC61")),
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+type RefreshTest struct {
+	Name     string
+	Status   Status
+	Expected Status
+}
+
+var (
+	refreshNow = time.Date(2024, 1, 20, 9, 15, 0, 0, time.UTC)
+
+	refreshTestCases = []RefreshTest{
+		{
+			Name: "Running session",
+			Status: Status{
+				StartTime: refreshNow.Add(-10 * time.Minute),
+				EndTime:   refreshNow.Add(15 * time.Minute),
+				Duration:  25 * time.Minute,
+			},
+			Expected: Status{
+				Elapsed:   10 * time.Minute,
+				Remaining: 15 * time.Minute,
+				Progress:  40,
+			},
+		},
+		{
+			Name: "Paused session",
+			Status: Status{
+				StartTime: refreshNow.Add(-time.Hour),
+				EndTime:   refreshNow.Add(-35 * time.Minute),
+				PausedAt:  refreshNow.Add(-50 * time.Minute),
+				Duration:  25 * time.Minute,
+				Remaining: 20 * time.Minute,
+				Paused:    true,
+			},
+			Expected: Status{
+				PausedAt:  refreshNow.Add(-50 * time.Minute),
+				Elapsed:   5 * time.Minute,
+				Remaining: 20 * time.Minute,
+				Progress:  20,
+				Paused:    true,
+			},
+		},
+		{
+			Name: "Ended session",
+			Status: Status{
+				StartTime: refreshNow.Add(-30 * time.Minute),
+				EndTime:   refreshNow.Add(-5 * time.Minute),
+				Duration:  25 * time.Minute,
+			},
+			Expected: Status{
+				Elapsed:  25 * time.Minute,
+				Progress: 100,
+			},
+		},
+		{
+			Name: "Session waiting to be started",
+			Status: Status{
+				Duration:  5 * time.Minute,
+				Remaining: 5 * time.Minute,
+				State:     StateWaiting,
+			},
+			Expected: Status{
+				Remaining: 5 * time.Minute,
+			},
+		},
+	}
+)
+
+func TestRefresh(t *testing.T) {
+	for _, tc := range refreshTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := tc.Status
+
+			s.Refresh(refreshNow)
+
+			if s.Paused != tc.Expected.Paused {
+				t.Errorf("expected paused to be %t, but got: %t", tc.Expected.Paused, s.Paused)
+			}
+
+			if !s.PausedAt.Equal(tc.Expected.PausedAt) {
+				t.Errorf("expected paused at to be %v, but got: %v", tc.Expected.PausedAt, s.PausedAt)
+			}
+
+			if s.Elapsed != tc.Expected.Elapsed {
+				t.Errorf("expected elapsed to be %v, but got: %v", tc.Expected.Elapsed, s.Elapsed)
+			}
+
+			if s.Remaining != tc.Expected.Remaining {
+				t.Errorf("expected remaining to be %v, but got: %v", tc.Expected.Remaining, s.Remaining)
+			}
+
+			if s.Progress != tc.Expected.Progress {
+				t.Errorf("expected progress to be %d, but got: %d", tc.Expected.Progress, s.Progress)
+			}
+		})
+	}
+}
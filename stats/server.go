@@ -11,11 +11,11 @@ import (
 	"net/http"
 	"os/exec"
 	"runtime"
-	"strings"
 	"time"
 
 	"github.com/pterm/pterm"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 )
 
@@ -47,11 +47,11 @@ func (h errorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func (s *Stats) getStats(
 	startTime, endTime time.Time,
-	tagList []string,
+	tags config.TagQuery,
 ) ([]byte, error) {
 	s.Opts.StartTime = startTime
 	s.Opts.EndTime = endTime
-	s.Opts.Tags = tagList
+	s.Opts.Tags = tags
 
 	sessions, err := s.DB.GetSessions(
 		s.Opts.StartTime,
@@ -62,6 +62,16 @@ func (s *Stats) getStats(
 		return nil, err
 	}
 
+	s.Tasks, err = s.DB.GetTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.LoadEstimates()
+	if err != nil {
+		return nil, err
+	}
+
 	s.Compute(sessions)
 
 	return s.ToJSON()
@@ -88,12 +98,13 @@ func (s *Stats) index(w http.ResponseWriter, r *http.Request) error {
 
 	endTime = timeutil.RoundToEnd(endTime)
 
-	var tagList []string
-	if tags != "" {
-		tagList = strings.Split(tags, ",")
+	tagQuery, err := config.ParseTagQuery(tags)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil
 	}
 
-	b, err := s.getStats(startTime, endTime, tagList)
+	b, err := s.getStats(startTime, endTime, tagQuery)
 	if err != nil {
 		return err
 	}
@@ -151,7 +162,7 @@ func (s *Stats) Server(port uint) error {
 
 	pterm.Info.Printfln("starting server on port: %d", port)
 
-	openbrowser("http://localhost:1111")
+	openbrowser(fmt.Sprintf("http://localhost:%d", port))
 
 	//nolint:gosec // no timeout is ok
 	return http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
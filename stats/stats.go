@@ -5,6 +5,7 @@ import (
 	"cmp"
 	"encoding/json"
 	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"strconv"
@@ -25,14 +26,60 @@ type (
 
 	// Stats represents the computed focus statistics for a period of time.
 	Stats struct {
-		Aggregates      Aggregates        `json:"aggregates"`
-		StartTime       time.Time         `json:"start_time"`
-		EndTime         time.Time         `json:"end_time"`
-		DB              store.DB          `json:"-"`
-		Opts            Opts              `json:"-"`
-		Sessions        []*models.Session `json:"-"`
-		LastDayTimeline []Timeline        `json:"timeline"`
-		Summary         Summary           `json:"summary"`
+		Aggregates    Aggregates            `json:"aggregates"`
+		StartTime     time.Time             `json:"start_time"`
+		EndTime       time.Time             `json:"end_time"`
+		DB            store.DB              `json:"-"`
+		Opts          Opts                  `json:"-"`
+		Sessions      []*models.Session     `json:"-"`
+		BreakSessions []*models.Session     `json:"-"`
+		Tasks         []*models.Task        `json:"-"`
+		Estimates     []*models.TagEstimate `json:"-"`
+		// EstimateSessions are the sessions since the earliest tag estimate.
+		// They may fall outside the reporting period
+		EstimateSessions []*models.Session `json:"-"`
+		LastDayTimeline  []Timeline        `json:"timeline"`
+		Summary          Summary           `json:"summary"`
+		Breaks           BreakSummary      `json:"breaks"`
+		TaskSummary      []TaskSummary     `json:"tasks"`
+		EstimateSummary  EstimateSummary   `json:"estimates"`
+	}
+
+	// TaskSummary reports the work recorded against a task in the current
+	// time period.
+	TaskSummary struct {
+		Name     string        `json:"name"`
+		ID       int           `json:"id"`
+		Duration time.Duration `json:"duration"`
+		// Completed is the number of completed work sessions
+		Completed int `json:"completed"`
+		// Estimate is the number of work sessions the task was expected to
+		// take
+		Estimate int `json:"estimate"`
+	}
+
+	// EstimateRecord compares a tag estimate with the work sessions that
+	// were completed on the tag while it applied.
+	EstimateRecord struct {
+		StartTime time.Time `json:"start_time"`
+		// EndTime is when the estimate was replaced, or the zero time if it
+		// still applies
+		EndTime   time.Time `json:"end_time"`
+		Tag       string    `json:"tag"`
+		Estimate  int       `json:"estimate"`
+		Completed int       `json:"completed"`
+		// Accuracy ranges from 0 to 1 where 1 means that the estimate
+		// matched the completed work sessions exactly
+		Accuracy float64 `json:"accuracy"`
+	}
+
+	// EstimateSummary reports how tag estimates that applied in the current
+	// time period compared with the work completed.
+	EstimateSummary struct {
+		Records []EstimateRecord `json:"records"`
+		// Accuracy is the average accuracy of the estimates that no longer
+		// apply
+		Accuracy float64 `json:"accuracy"`
 	}
 
 	Timeline struct {
@@ -67,6 +114,9 @@ type (
 			Abandoned int           `json:"abandoned"`
 			Duration  time.Duration `json:"duration"`
 		} `json:"averages"`
+		Breaks    BreakSummary    `json:"breaks"`
+		Tasks     []TaskSummary   `json:"tasks"`
+		Estimates EstimateSummary `json:"estimates"`
 	}
 
 	aggregatePeriod string
@@ -81,6 +131,17 @@ type (
 		AvgTime      time.Duration            `json:"avg_time"`
 	}
 
+	// BreakSummary reports how break sessions were spent in the current
+	// time period.
+	BreakSummary struct {
+		TotalTime      time.Duration `json:"total_time"`
+		ShortBreaks    int           `json:"short_breaks"`
+		LongBreaks     int           `json:"long_breaks"`
+		Completed      int           `json:"completed"`
+		Skipped        int           `json:"skipped"`
+		WorkBreakRatio float64       `json:"work_break_ratio"`
+	}
+
 	Aggregates struct {
 		startTime time.Time
 		endTime   time.Time
@@ -377,6 +438,175 @@ func (s *Stats) computeSummary() {
 	s.Summary = totals
 }
 
+// computeBreakSummary calculates the total break time, the number of breaks
+// taken or skipped, and the ratio of work to break time for the current
+// time period.
+func (s *Stats) computeBreakSummary() {
+	var totals BreakSummary
+
+	for i := range s.BreakSessions {
+		sess := s.BreakSessions[i]
+
+		totals.TotalTime += s.getSessionDuration(sess)
+
+		if sess.Name == config.LongBreak {
+			totals.LongBreaks++
+		} else {
+			totals.ShortBreaks++
+		}
+
+		if sess.Completed {
+			totals.Completed++
+		}
+
+		if sess.Skipped {
+			totals.Skipped++
+		}
+	}
+
+	if totals.TotalTime > 0 {
+		totals.WorkBreakRatio = float64(
+			s.Summary.TotalTime,
+		) / float64(
+			totals.TotalTime,
+		)
+	}
+
+	s.Breaks = totals
+}
+
+// computeTaskSummary calculates the time spent and the work sessions
+// completed on each task in the current time period.
+func (s *Stats) computeTaskSummary() {
+	tasks := make(map[int]*models.Task, len(s.Tasks))
+	for _, v := range s.Tasks {
+		tasks[v.ID] = v
+	}
+
+	totals := make(map[int]*TaskSummary)
+
+	for _, sess := range s.Sessions {
+		if sess.TaskID == 0 {
+			continue
+		}
+
+		summary, ok := totals[sess.TaskID]
+		if !ok {
+			summary = &TaskSummary{ID: sess.TaskID}
+
+			if task, ok := tasks[sess.TaskID]; ok {
+				summary.Name = task.Name
+				summary.Estimate = task.Estimate
+			}
+
+			totals[sess.TaskID] = summary
+		}
+
+		summary.Duration += s.getSessionDuration(sess)
+
+		if sess.Completed {
+			summary.Completed++
+		}
+	}
+
+	s.TaskSummary = make([]TaskSummary, 0, len(totals))
+
+	for _, v := range totals {
+		s.TaskSummary = append(s.TaskSummary, *v)
+	}
+
+	slices.SortStableFunc(s.TaskSummary, func(a, b TaskSummary) int {
+		return cmp.Or(
+			cmp.Compare(b.Duration, a.Duration),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+}
+
+// estimateAccuracy scores how close the completed work sessions were to the
+// estimate from 0 to 1.
+func estimateAccuracy(estimate, completed int) float64 {
+	diff := math.Abs(float64(completed - estimate))
+
+	return math.Max(0, 1-diff/float64(estimate))
+}
+
+// computeEstimateSummary compares the tag estimates that applied in the
+// current time period with the work sessions completed on each tag until the
+// estimate was replaced.
+func (s *Stats) computeEstimateSummary() {
+	var summary EstimateSummary
+
+	for i, est := range s.Estimates {
+		if est.Estimate == 0 {
+			continue
+		}
+
+		rec := EstimateRecord{
+			StartTime: est.StartTime,
+			Tag:       est.Tag,
+			Estimate:  est.Estimate,
+		}
+
+		for _, v := range s.Estimates[i+1:] {
+			if v.Tag == est.Tag {
+				rec.EndTime = v.StartTime
+				break
+			}
+		}
+
+		if rec.StartTime.After(s.Opts.EndTime) ||
+			(!rec.EndTime.IsZero() && rec.EndTime.Before(s.Opts.StartTime)) {
+			continue
+		}
+
+		rec.Completed = est.CountCompleted(s.EstimateSessions, rec.EndTime)
+
+		rec.Accuracy = estimateAccuracy(rec.Estimate, rec.Completed)
+
+		summary.Records = append(summary.Records, rec)
+	}
+
+	var ended int
+
+	for _, v := range summary.Records {
+		if !v.EndTime.IsZero() {
+			summary.Accuracy += v.Accuracy
+			ended++
+		}
+	}
+
+	if ended > 0 {
+		summary.Accuracy /= float64(ended)
+	}
+
+	s.EstimateSummary = summary
+}
+
+// LoadEstimates retrieves the tag estimates and the sessions needed to
+// evaluate them from the database.
+func (s *Stats) LoadEstimates() error {
+	estimates, err := s.DB.GetTagEstimates()
+	if err != nil {
+		return err
+	}
+
+	s.Estimates = estimates
+	s.EstimateSessions = nil
+
+	if len(estimates) == 0 {
+		return nil
+	}
+
+	s.EstimateSessions, err = s.DB.GetSessions(
+		estimates[0].StartTime,
+		time.Now(),
+		nil,
+	)
+
+	return err
+}
+
 func sortByName(recs []Record) {
 	slices.SortStableFunc(recs, func(a, b Record) int {
 		return cmp.Compare(a.Name, b.Name)
@@ -426,7 +656,9 @@ func sortWeekdays(recs []Record) {
 	})
 }
 
-func (s *Stats) ToJSON() ([]byte, error) {
+// records converts the computed statistics into sorted records suitable for
+// presentation.
+func (s *Stats) records() *statsJSON {
 	var r statsJSON
 
 	r.StartTime = s.StartTime
@@ -441,6 +673,10 @@ func (s *Stats) ToJSON() ([]byte, error) {
 
 	r.LastDayTimeline = s.LastDayTimeline
 
+	r.Breaks = s.Breaks
+	r.Tasks = s.TaskSummary
+	r.Estimates = s.EstimateSummary
+
 	for k, v := range s.Summary.Tags {
 		r.Tags = append(r.Tags, Record{
 			Name:     k,
@@ -501,12 +737,29 @@ func (s *Stats) ToJSON() ([]byte, error) {
 	sortMonths(r.Monthly)
 	sortByName(r.Yearly)
 
-	return json.Marshal(r)
+	return &r
+}
+
+// ToJSON returns the computed statistics in JSON format.
+func (s *Stats) ToJSON() ([]byte, error) {
+	return json.Marshal(s.records())
 }
 
-// Compute calculates Focus statistics for a specific time period.
+// Compute calculates Focus statistics for a specific time period. Break
+// sessions are reported separately so that they don't affect the work
+// session aggregates.
 func (s *Stats) Compute(sessions []*models.Session) {
-	s.Sessions = sessions
+	s.Sessions = nil
+	s.BreakSessions = nil
+
+	for _, sess := range sessions {
+		if sess.IsBreak() {
+			s.BreakSessions = append(s.BreakSessions, sess)
+			continue
+		}
+
+		s.Sessions = append(s.Sessions, sess)
+	}
 
 	// TODO: Filter invalid sessions?
 
@@ -519,5 +772,8 @@ func (s *Stats) Compute(sessions []*models.Session) {
 	s.EndTime = s.Opts.EndTime
 
 	s.computeSummary()
+	s.computeBreakSummary()
+	s.computeTaskSummary()
+	s.computeEstimateSummary()
 	s.computeAggregates()
 }
@@ -0,0 +1,369 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+type BreakSummaryTest struct {
+	Name     string
+	Sessions []*models.Session
+	Summary  Summary
+	Breaks   BreakSummary
+}
+
+var periodStart = time.Date(2024, time.March, 4, 0, 0, 0, 0, time.Local)
+
+func testSession(
+	name config.SessType,
+	offset, duration time.Duration,
+	completed, skipped bool,
+) *models.Session {
+	start := periodStart.Add(offset)
+	end := start.Add(duration)
+
+	return &models.Session{
+		Name:      name,
+		StartTime: start,
+		EndTime:   end,
+		Duration:  duration,
+		Completed: completed,
+		Skipped:   skipped,
+		Timeline: []models.SessionTimeline{
+			{StartTime: start, EndTime: end},
+		},
+	}
+}
+
+var breakSummaryTestCases = []BreakSummaryTest{
+	{
+		Name: "Work sessions only",
+		Sessions: []*models.Session{
+			testSession(config.Work, 9*time.Hour, 25*time.Minute, true, false),
+			testSession(config.Work, 10*time.Hour, 10*time.Minute, false, false),
+		},
+		Summary: Summary{
+			TotalTime: 35 * time.Minute,
+			Completed: 1,
+			Abandoned: 1,
+		},
+	},
+	{
+		Name: "Breaks do not affect work totals",
+		Sessions: []*models.Session{
+			testSession(config.Work, 9*time.Hour, 25*time.Minute, true, false),
+			testSession(
+				config.ShortBreak,
+				9*time.Hour+25*time.Minute,
+				5*time.Minute,
+				true,
+				false,
+			),
+			testSession(config.Work, 10*time.Hour, 25*time.Minute, true, false),
+			testSession(
+				config.ShortBreak,
+				10*time.Hour+25*time.Minute,
+				2*time.Minute,
+				false,
+				true,
+			),
+			testSession(config.Work, 11*time.Hour, 25*time.Minute, true, false),
+			testSession(
+				config.LongBreak,
+				11*time.Hour+25*time.Minute,
+				8*time.Minute,
+				true,
+				false,
+			),
+		},
+		Summary: Summary{
+			TotalTime: 75 * time.Minute,
+			Completed: 3,
+		},
+		Breaks: BreakSummary{
+			TotalTime:      15 * time.Minute,
+			ShortBreaks:    2,
+			LongBreaks:     1,
+			Completed:      2,
+			Skipped:        1,
+			WorkBreakRatio: 5,
+		},
+	},
+}
+
+func TestBreakSummary(t *testing.T) {
+	for _, tc := range breakSummaryTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &Stats{
+				Opts: Opts{
+					FilterConfig: config.FilterConfig{
+						StartTime: periodStart,
+						EndTime:   periodStart.Add(24*time.Hour - time.Second),
+					},
+				},
+			}
+
+			s.Compute(tc.Sessions)
+
+			if s.Summary.TotalTime != tc.Summary.TotalTime ||
+				s.Summary.Completed != tc.Summary.Completed ||
+				s.Summary.Abandoned != tc.Summary.Abandoned {
+				t.Errorf(
+					"expected work summary to be: %+v, but got: %+v",
+					tc.Summary,
+					s.Summary,
+				)
+			}
+
+			if s.Breaks != tc.Breaks {
+				t.Errorf(
+					"expected break summary to be: %+v, but got: %+v",
+					tc.Breaks,
+					s.Breaks,
+				)
+			}
+		})
+	}
+}
+
+type TaskSummaryTest struct {
+	Name     string
+	Sessions []*models.Session
+	Tasks    []*models.Task
+	Expected []TaskSummary
+}
+
+// taskSession returns a work session recorded against the specified task.
+func taskSession(
+	taskID int,
+	offset, duration time.Duration,
+	completed bool,
+) *models.Session {
+	sess := testSession(config.Work, offset, duration, completed, false)
+	sess.TaskID = taskID
+
+	return sess
+}
+
+var taskSummaryTestCases = []TaskSummaryTest{
+	{
+		Name: "No tasks",
+		Sessions: []*models.Session{
+			testSession(config.Work, 9*time.Hour, 25*time.Minute, true, false),
+		},
+		Expected: []TaskSummary{},
+	},
+	{
+		Name: "Sessions recorded against tasks",
+		Sessions: []*models.Session{
+			taskSession(1, 9*time.Hour, 25*time.Minute, true),
+			taskSession(2, 10*time.Hour, 25*time.Minute, true),
+			taskSession(2, 11*time.Hour, 25*time.Minute, true),
+			taskSession(2, 12*time.Hour, 10*time.Minute, false),
+			testSession(config.Work, 13*time.Hour, 25*time.Minute, true, false),
+		},
+		Tasks: []*models.Task{
+			{ID: 1, Name: "Write report", Estimate: 4},
+			{ID: 2, Name: "Review PRs"},
+		},
+		Expected: []TaskSummary{
+			{ID: 2, Name: "Review PRs", Duration: 60 * time.Minute, Completed: 2},
+			{
+				ID:        1,
+				Name:      "Write report",
+				Duration:  25 * time.Minute,
+				Completed: 1,
+				Estimate:  4,
+			},
+		},
+	},
+	{
+		Name: "Deleted task",
+		Sessions: []*models.Session{
+			taskSession(3, 9*time.Hour, 25*time.Minute, true),
+		},
+		Expected: []TaskSummary{
+			{ID: 3, Duration: 25 * time.Minute, Completed: 1},
+		},
+	},
+}
+
+func TestTaskSummary(t *testing.T) {
+	for _, tc := range taskSummaryTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &Stats{
+				Opts: Opts{
+					FilterConfig: config.FilterConfig{
+						StartTime: periodStart,
+						EndTime:   periodStart.Add(24*time.Hour - time.Second),
+					},
+				},
+				Tasks: tc.Tasks,
+			}
+
+			s.Compute(tc.Sessions)
+
+			if !slices.Equal(s.TaskSummary, tc.Expected) {
+				t.Errorf(
+					"expected task summary to be: %+v, but got: %+v",
+					tc.Expected,
+					s.TaskSummary,
+				)
+			}
+		})
+	}
+}
+
+type EstimateSummaryTest struct {
+	Name      string
+	Sessions  []*models.Session
+	Estimates []*models.TagEstimate
+	Expected  []EstimateRecord
+	Accuracy  float64
+}
+
+// taggedSession returns a completed work session with the specified tags.
+func taggedSession(offset time.Duration, tags ...string) *models.Session {
+	sess := testSession(config.Work, offset, 25*time.Minute, true, false)
+	sess.Tags = tags
+
+	return sess
+}
+
+var estimateSummaryTestCases = []EstimateSummaryTest{
+	{
+		Name: "Estimate that still applies",
+		Sessions: []*models.Session{
+			taggedSession(9*time.Hour, "writing"),
+			taggedSession(10*time.Hour, "design"),
+		},
+		Estimates: []*models.TagEstimate{
+			{StartTime: periodStart, Tag: "writing", Estimate: 4},
+		},
+		Expected: []EstimateRecord{
+			{
+				StartTime: periodStart,
+				Tag:       "writing",
+				Estimate:  4,
+				Completed: 1,
+				Accuracy:  0.25,
+			},
+		},
+	},
+	{
+		Name: "Replaced and removed estimates",
+		Sessions: []*models.Session{
+			taggedSession(-2*time.Hour, "writing"),
+			taggedSession(9*time.Hour, "writing"),
+			taggedSession(10*time.Hour, "writing", "design"),
+			taggedSession(11*time.Hour, "writing"),
+			taggedSession(13*time.Hour, "writing"),
+			taggedSession(14*time.Hour, "writing"),
+		},
+		Estimates: []*models.TagEstimate{
+			{StartTime: periodStart.Add(-3 * time.Hour), Tag: "writing", Estimate: 4},
+			{StartTime: periodStart.Add(12 * time.Hour), Tag: "writing", Estimate: 1},
+			{StartTime: periodStart.Add(15 * time.Hour), Tag: "writing"},
+		},
+		Expected: []EstimateRecord{
+			{
+				StartTime: periodStart.Add(-3 * time.Hour),
+				EndTime:   periodStart.Add(12 * time.Hour),
+				Tag:       "writing",
+				Estimate:  4,
+				Completed: 4,
+				Accuracy:  1,
+			},
+			{
+				StartTime: periodStart.Add(12 * time.Hour),
+				EndTime:   periodStart.Add(15 * time.Hour),
+				Tag:       "writing",
+				Estimate:  1,
+				Completed: 2,
+				Accuracy:  0,
+			},
+		},
+		Accuracy: 0.5,
+	},
+	{
+		Name: "Estimate replaced before the period",
+		Estimates: []*models.TagEstimate{
+			{StartTime: periodStart.Add(-3 * time.Hour), Tag: "writing", Estimate: 4},
+			{StartTime: periodStart.Add(-2 * time.Hour), Tag: "writing"},
+		},
+	},
+}
+
+func TestEstimateSummary(t *testing.T) {
+	for _, tc := range estimateSummaryTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &Stats{
+				Opts: Opts{
+					FilterConfig: config.FilterConfig{
+						StartTime: periodStart,
+						EndTime:   periodStart.Add(24*time.Hour - time.Second),
+					},
+				},
+				Estimates:        tc.Estimates,
+				EstimateSessions: tc.Sessions,
+			}
+
+			s.Compute(nil)
+
+			if !slices.Equal(s.EstimateSummary.Records, tc.Expected) {
+				t.Errorf(
+					"expected estimates to be: %+v, but got: %+v",
+					tc.Expected,
+					s.EstimateSummary.Records,
+				)
+			}
+
+			if s.EstimateSummary.Accuracy != tc.Accuracy {
+				t.Errorf(
+					"expected accuracy to be: %v, but got: %v",
+					tc.Accuracy,
+					s.EstimateSummary.Accuracy,
+				)
+			}
+		})
+	}
+}
+
+type HeatmapColorsTest struct {
+	Name     string
+	Sessions []*models.Session
+	Colors   int
+}
+
+var heatmapColorsTestCases = []HeatmapColorsTest{
+	{
+		Name:   "No sessions",
+		Colors: 1,
+	},
+	{
+		Name: "One session",
+		Sessions: []*models.Session{
+			testSession(config.Work, 9*time.Hour, 25*time.Minute, true, false),
+		},
+		Colors: 3,
+	},
+}
+
+func TestHeatmapColors(t *testing.T) {
+	for _, tc := range heatmapColorsTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &Stats{Sessions: tc.Sessions}
+			s.Opts.StartTime = periodStart
+			s.Opts.EndTime = periodStart.AddDate(0, 0, 7)
+
+			colors := heatmapColors(s.hourlyHeatmap())
+			if len(colors) != tc.Colors {
+				t.Errorf("expected %d colours, but got: %d", tc.Colors, len(colors))
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+// formatDuration formats a duration in hours and minutes (e.g. 2h 15m).
+func formatDuration(d time.Duration) string {
+	hrs, mins := timeutil.MinsToHoursAndMins(int(d.Minutes()))
+
+	if mins == 0 {
+		return fmt.Sprintf("%dh", hrs)
+	}
+
+	return fmt.Sprintf("%dh %dm", hrs, mins)
+}
+
+// mainChart returns the title and records of the chart that best represents
+// the reporting period. It mirrors the main chart in the web interface.
+func mainChart(r *statsJSON) (string, []Record) {
+	days := r.EndTime.Sub(r.StartTime).Hours() / timeutil.HoursInADay
+
+	switch {
+	case days > 366:
+		return "Yearly totals", r.Yearly
+	case days > 90:
+		return "Monthly totals", r.Monthly
+	case days > 45:
+		return "Weekly totals", r.Weekly
+	}
+
+	recs := make([]Record, len(r.Daily))
+
+	for i, v := range r.Daily {
+		recs[i] = v
+
+		date, err := time.ParseInLocation("2006-01-02", v.Name, time.Local)
+		if err == nil {
+			recs[i].Name = date.Format("Mon, Jan 02")
+		}
+	}
+
+	return "Daily totals", recs
+}
+
+// hourlyHeatmap returns the minutes focused in each hour of the day (columns)
+// for each day of the week (rows) within the reporting period.
+func (s *Stats) hourlyHeatmap() pterm.HeatmapData {
+	data := make(pterm.HeatmapData, 7)
+	for i := range data {
+		data[i] = make([]float32, 24)
+	}
+
+	for _, sess := range s.Sessions {
+		for _, event := range sess.Timeline {
+			for date := event.StartTime; date.Before(event.EndTime); date = date.Add(1 * time.Minute) {
+				if date.Before(s.Opts.StartTime) {
+					continue
+				}
+
+				if date.After(s.Opts.EndTime) {
+					break
+				}
+
+				data[date.Weekday()][date.Hour()]++
+			}
+		}
+	}
+
+	return data
+}
+
+// heatmapColors returns the colours of the heatmap cells from the lowest to the
+// highest intensity. Without any data, every cell would otherwise be rendered
+// in the highest intensity colour, so only the colour of empty cells is used.
+func heatmapColors(data pterm.HeatmapData) []pterm.Color {
+	colors := []pterm.Color{pterm.BgDarkGray, pterm.BgGreen, pterm.BgLightGreen}
+
+	empty := !slices.ContainsFunc(data, func(row []float32) bool {
+		return slices.ContainsFunc(row, func(v float32) bool {
+			return v > 0
+		})
+	})
+
+	if empty {
+		return colors[:1]
+	}
+
+	return colors
+}
+
+// barChart renders the records as a horizontal bar chart of minutes.
+func barChart(recs []Record, label func(Record) string) (string, error) {
+	bars := make(pterm.Bars, 0, len(recs))
+
+	for _, v := range recs {
+		bars = append(bars, pterm.Bar{
+			Label: label(v),
+			Value: int(v.Duration.Minutes()),
+		})
+	}
+
+	return pterm.DefaultBarChart.WithHorizontal().WithBars(bars).Srender()
+}
+
+// summaryTable renders the totals and averages for the reporting period.
+func (s *Stats) summaryTable() (string, error) {
+	ratio := "-"
+	if s.Breaks.WorkBreakRatio > 0 {
+		ratio = fmt.Sprintf("%.1f:1", s.Breaks.WorkBreakRatio)
+	}
+
+	data := pterm.TableData{
+		{
+			"Period",
+			fmt.Sprintf(
+				"%s — %s",
+				s.StartTime.Format("Jan 02, 2006"),
+				s.EndTime.Format("Jan 02, 2006"),
+			),
+		},
+		{
+			"Focused for",
+			fmt.Sprintf(
+				"%s (%s per day)",
+				formatDuration(s.Summary.TotalTime),
+				formatDuration(s.Summary.AvgTime),
+			),
+		},
+		{
+			"Completed sessions",
+			fmt.Sprintf(
+				"%d (%d per day)",
+				s.Summary.Completed,
+				s.Summary.AvgCompleted,
+			),
+		},
+		{
+			"Abandoned sessions",
+			fmt.Sprintf(
+				"%d (%d per day)",
+				s.Summary.Abandoned,
+				s.Summary.AvgAbandoned,
+			),
+		},
+		{"Break time", formatDuration(s.Breaks.TotalTime)},
+		{
+			"Breaks taken",
+			strconv.Itoa(s.Breaks.ShortBreaks + s.Breaks.LongBreaks),
+		},
+		{"Skipped breaks", strconv.Itoa(s.Breaks.Skipped)},
+		{"Work/break ratio", ratio},
+	}
+
+	return pterm.DefaultTable.WithBoxed().WithData(data).Srender()
+}
+
+// tasksTable renders the time spent and the work sessions completed on each
+// task alongside the estimate for the task.
+func (s *Stats) tasksTable() (string, error) {
+	data := pterm.TableData{
+		{"Task", "Focused for", "Pomodoros", "Estimate"},
+	}
+
+	for _, v := range s.TaskSummary {
+		estimate := "-"
+		if v.Estimate > 0 {
+			estimate = fmt.Sprintf(
+				"%d (%.0f%%)",
+				v.Estimate,
+				float64(v.Completed)/float64(v.Estimate)*100,
+			)
+		}
+
+		data = append(data, []string{
+			strings.TrimSpace(fmt.Sprintf("#%d %s", v.ID, v.Name)),
+			formatDuration(v.Duration),
+			strconv.Itoa(v.Completed),
+			estimate,
+		})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(data).Srender()
+}
+
+// estimatesTable renders each tag estimate that applied in the reporting
+// period alongside the work sessions completed on the tag. The accuracy of an
+// estimate that still applies isn't final, so it is left out.
+func (s *Stats) estimatesTable() (string, error) {
+	data := pterm.TableData{
+		{"Tag", "Period", "Estimate", "Completed", "Accuracy"},
+	}
+
+	for _, v := range s.EstimateSummary.Records {
+		until, accuracy := "now", "-"
+		if !v.EndTime.IsZero() {
+			until = v.EndTime.Format("Jan 02, 2006")
+			accuracy = fmt.Sprintf("%.0f%%", v.Accuracy*100)
+		}
+
+		data = append(data, []string{
+			v.Tag,
+			fmt.Sprintf("%s — %s", v.StartTime.Format("Jan 02, 2006"), until),
+			strconv.Itoa(v.Estimate),
+			strconv.Itoa(v.Completed),
+			accuracy,
+		})
+	}
+
+	table, err := pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(data).Srender()
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range s.EstimateSummary.Records {
+		if !v.EndTime.IsZero() {
+			return fmt.Sprintf(
+				"%s\nAverage accuracy: %.0f%%",
+				table,
+				s.EstimateSummary.Accuracy*100,
+			), nil
+		}
+	}
+
+	return table, nil
+}
+
+// Report prints the statistics to the terminal for environments where a
+// browser is not available.
+func (s *Stats) Report(w io.Writer) error {
+	r := s.records()
+
+	summary, err := s.summaryTable()
+	if err != nil {
+		return err
+	}
+
+	title, recs := mainChart(r)
+
+	main, err := barChart(recs, func(v Record) string {
+		return fmt.Sprintf("%s (%s)", v.Name, formatDuration(v.Duration))
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(w, pterm.DefaultSection.Sprint("Summary"))
+	fmt.Fprintln(w, summary)
+
+	fmt.Fprint(w, pterm.DefaultSection.Sprint(title))
+	fmt.Fprintln(w, main)
+
+	if s.Summary.TotalTime > 0 {
+		tags, err := barChart(r.Tags, func(v Record) string {
+			percent := float64(v.Duration) / float64(s.Summary.TotalTime) * 100
+
+			return fmt.Sprintf(
+				"%s (%s, %.0f%%)",
+				v.Name,
+				formatDuration(v.Duration),
+				percent,
+			)
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(w, pterm.DefaultSection.Sprint("Tags"))
+		fmt.Fprintln(w, tags)
+	}
+
+	if len(s.TaskSummary) > 0 {
+		tasks, err := s.tasksTable()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(w, pterm.DefaultSection.Sprint("Tasks"))
+		fmt.Fprintln(w, tasks)
+	}
+
+	if len(s.EstimateSummary.Records) > 0 {
+		estimates, err := s.estimatesTable()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(w, pterm.DefaultSection.Sprint("Estimates"))
+		fmt.Fprintln(w, estimates)
+	}
+
+	weekdays := make([]string, 7)
+	for i := range weekdays {
+		weekdays[i] = time.Weekday(i).String()[:3]
+	}
+
+	hours := make([]string, 24)
+	for i := range hours {
+		hours[i] = fmt.Sprintf("%02d", i)
+	}
+
+	data := s.hourlyHeatmap()
+
+	heatmap, err := pterm.DefaultHeatmap.
+		WithData(data).
+		WithAxisData(pterm.HeatmapAxis{XAxis: hours, YAxis: weekdays}).
+		WithGrid(false).
+		WithOnlyColoredCells().
+		WithColors(heatmapColors(data)...).
+		Srender()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(w, pterm.DefaultSection.Sprint("Hourly heatmap"))
+	fmt.Fprintln(w, heatmap)
+
+	return nil
+}
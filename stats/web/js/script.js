@@ -97,6 +97,17 @@ function plotSummary(data) {
   )})`;
   document.querySelector('#js-completed').textContent = data.totals.completed;
   document.querySelector('#js-abandoned').textContent = data.totals.abandoned;
+  document.querySelector('#js-break-time').textContent = toHoursAndMinutes(
+    Math.floor(data.breaks.total_time / 60000000000)
+  );
+  document.querySelector('#js-breaks-taken').textContent =
+    data.breaks.short_breaks + data.breaks.long_breaks;
+  document.querySelector('#js-breaks-skipped').textContent =
+    data.breaks.skipped;
+  document.querySelector('#js-work-break-ratio').textContent =
+    data.breaks.work_break_ratio > 0
+      ? `${data.breaks.work_break_ratio.toFixed(1)}:1`
+      : '-';
 }
 
 function getChartOptions(seriesData, xaxisCategories, title) {
@@ -300,9 +311,12 @@ document.addEventListener('DOMContentLoaded', async () => {
       setup(picker) {
         picker.on('select', (e) => {
           const { start, end } = e.detail;
-          window.location.href = `${
-            window.location.pathname
-          }?start_time=${formatDate(start)}&end_time=${formatDate(end)}`;
+          const params = new URLSearchParams(window.location.search);
+
+          params.set('start_time', formatDate(start));
+          params.set('end_time', formatDate(end));
+
+          window.location.href = `${window.location.pathname}?${params}`;
         });
       },
     });
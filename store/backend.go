@@ -0,0 +1,222 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	BackendBolt   = "bbolt"
+	BackendJSONL  = "jsonl"
+	BackendMemory = "memory"
+)
+
+var (
+	errUnknownBackend = errors.New("unknown storage backend")
+
+	errDestinationNotEmpty = errors.New(
+		"the destination database already contains sessions",
+	)
+)
+
+// Backend describes how to open a storage backend.
+type Backend struct {
+	// Open connects to the data at the specified path
+	Open func(path string) (DB, error)
+	// OpenReadOnly connects to the data for reading while it may be in use
+	// by a running timer. Open is used if it is not set
+	OpenReadOnly func(path string) (DB, error)
+	// Ext is the extension of the file that holds the data
+	Ext string
+}
+
+var backends = map[string]Backend{
+	BackendBolt: {
+		Open: func(path string) (DB, error) {
+			c, err := NewClient(path)
+			if err != nil {
+				return nil, err
+			}
+
+			return c, nil
+		},
+		OpenReadOnly: func(path string) (DB, error) {
+			c, err := NewReadOnlyClient(path)
+			if err != nil {
+				return nil, err
+			}
+
+			return c, nil
+		},
+		Ext: ".db",
+	},
+	BackendJSONL: {
+		Open: func(path string) (DB, error) {
+			j, err := NewJSONL(path)
+			if err != nil {
+				return nil, err
+			}
+
+			return j, nil
+		},
+		Ext: ".jsonl",
+	},
+	BackendMemory: {
+		Open: func(_ string) (DB, error) {
+			return NewMemory(), nil
+		},
+	},
+}
+
+// Register makes a storage backend available under the provided name,
+// replacing any backend previously registered with that name.
+func Register(name string, b Backend) {
+	backends[name] = b
+}
+
+// Backends returns the names of the registered storage backends.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+
+	for k := range backends {
+		names = append(names, k)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Path returns the location of the data for the named backend, which is the
+// default database path with the extension of the backend.
+func Path(name, dbFilePath string) string {
+	b, ok := backends[name]
+	if !ok || b.Ext == "" {
+		return dbFilePath
+	}
+
+	return strings.TrimSuffix(dbFilePath, filepath.Ext(dbFilePath)) + b.Ext
+}
+
+// lookup returns the named storage backend.
+func lookup(name string) (Backend, error) {
+	b, ok := backends[name]
+	if !ok {
+		return Backend{}, fmt.Errorf(
+			"%w %q: must be one of %s",
+			errUnknownBackend,
+			name,
+			strings.Join(Backends(), ", "),
+		)
+	}
+
+	return b, nil
+}
+
+// Open connects to the named storage backend with its data stored alongside
+// the default database path.
+func Open(name, dbFilePath string) (DB, error) {
+	b, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Open(Path(name, dbFilePath))
+}
+
+// OpenReadOnly connects to the named storage backend for reading. Unlike Open,
+// it succeeds while a timer is active.
+func OpenReadOnly(name, dbFilePath string) (DB, error) {
+	b, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.OpenReadOnly == nil {
+		return b.Open(Path(name, dbFilePath))
+	}
+
+	return b.OpenReadOnly(Path(name, dbFilePath))
+}
+
+// Convert copies all the sessions, paused timers, tasks, and tag estimates
+// from src to dst in a single batch, and returns the number of sessions copied. The destination
+// must not contain any sessions. If dryRun is set, nothing is written.
+func Convert(dst, src DB, dryRun bool) (int, error) {
+	// sessions are keyed by their start time, so this covers all of them
+	until := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	existing, err := dst.GetSessions(time.Time{}, until, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(existing) > 0 {
+		return 0, errDestinationNotEmpty
+	}
+
+	sessions, err := src.GetSessions(time.Time{}, until, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	timers, err := src.RetrievePausedTimers()
+	if err != nil {
+		return 0, err
+	}
+
+	tasks, err := src.GetTasks()
+	if err != nil {
+		return 0, err
+	}
+
+	estimates, err := src.GetTagEstimates()
+	if err != nil {
+		return 0, err
+	}
+
+	if dryRun {
+		return len(sessions), nil
+	}
+
+	err = dst.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, v := range timers {
+			err := b.PutTimer(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, v := range tasks {
+			err := b.PutTask(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, v := range estimates {
+			err := b.PutTagEstimate(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sessions), nil
+}
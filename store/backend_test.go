@@ -0,0 +1,318 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// openTestBackend opens the named backend in a temporary directory.
+func openTestBackend(t *testing.T, name string) DB {
+	t.Helper()
+
+	db, err := Open(name, filepath.Join(t.TempDir(), "focus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+// putSessions saves the provided sessions in a single batch.
+func putSessions(t *testing.T, db DB, sessions []*models.Session) {
+	t.Helper()
+
+	err := db.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestBackends checks that every backend behaves in the same way.
+func TestBackends(t *testing.T) {
+	for _, name := range Backends() {
+		t.Run(name, func(t *testing.T) {
+			db := openTestBackend(t, name)
+
+			sessions := testSessions(4)
+			sessions[0].Tags = []string{"writing"}
+
+			putSessions(t, db, sessions)
+
+			err := db.UpdateTimer(&models.Timer{
+				SessionKey: sessions[3].StartTime,
+				PausedTime: sessions[3].EndTime,
+				WorkCycle:  2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			errAbort := errors.New("abort")
+
+			err = db.BatchUpdate(func(b Batch) error {
+				err := b.DeleteSession(sessions[1].StartTime)
+				if err != nil {
+					return err
+				}
+
+				return errAbort
+			})
+			if !errors.Is(err, errAbort) {
+				t.Fatalf("expected error to be: %v, but got: %v", errAbort, err)
+			}
+
+			err = db.DeleteSessions([]time.Time{sessions[2].StartTime})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			q, _ := config.ParseTagQuery("!writing")
+
+			got, err := db.GetSessions(
+				testStart.Add(10*time.Minute),
+				testStart.AddDate(0, 0, 1),
+				q,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != 2 ||
+				!got[0].StartTime.Equal(sessions[1].StartTime) ||
+				!got[1].StartTime.Equal(sessions[3].StartTime) {
+				t.Errorf("expected sessions 2 and 4, but got: %v", got)
+			}
+
+			_, err = db.GetSession(sessions[2].StartTime)
+			if !errors.Is(err, ErrSessionNotFound) {
+				t.Errorf("expected error to be: %v, but got: %v", ErrSessionNotFound, err)
+			}
+
+			timers, err := db.RetrievePausedTimers()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(timers) != 1 || timers[0].WorkCycle != 2 {
+				t.Errorf("expected the paused timer to be retrieved, but got: %v", timers)
+			}
+		})
+	}
+}
+
+func TestJSONLReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.jsonl")
+
+	j, err := NewJSONL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := testSessions(3)
+
+	putSessions(t, j, sessions)
+
+	sessions[0].Tags = []string{"writing"}
+
+	putSessions(t, j, sessions[:1])
+
+	err = j.DeleteSessions([]time.Time{sessions[1].StartTime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j.Close()
+
+	err = j.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer j.Close()
+
+	got, err := j.GetSessions(time.Time{}, testStart.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0].Tags[0] != "writing" {
+		t.Errorf("expected the changes to be replayed, but got: %v", got)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	src := openTestBackend(t, BackendBolt)
+	dst := openTestBackend(t, BackendJSONL)
+
+	putSessions(t, src, testSessions(5))
+
+	n, err := Convert(dst, src, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 5 {
+		t.Errorf("expected 5 sessions to be copied, but got: %d", n)
+	}
+
+	_, err = Convert(dst, src, false)
+	if !errors.Is(err, errDestinationNotEmpty) {
+		t.Errorf("expected error to be: %v, but got: %v", errDestinationNotEmpty, err)
+	}
+}
+
+// TestTasks checks that every backend numbers and saves tasks in the same way.
+func TestTasks(t *testing.T) {
+	for _, name := range Backends() {
+		t.Run(name, func(t *testing.T) {
+			db := openTestBackend(t, name)
+
+			for _, v := range []string{"Outline", "Draft"} {
+				task := &models.Task{Name: v, CreatedAt: testStart}
+
+				err := db.UpdateTask(task)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if task.Name == "Draft" && task.ID != 2 {
+					t.Errorf("expected the second task to have ID 2, but got: %d", task.ID)
+				}
+			}
+
+			// tasks copied from another database keep their IDs
+			err := db.BatchUpdate(func(b Batch) error {
+				err := b.PutTask(&models.Task{ID: 5, Name: "Edit"})
+				if err != nil {
+					return err
+				}
+
+				return b.PutTask(&models.Task{Name: "Publish"})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			task, err := db.GetTask(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			task.DoneAt = testStart.Add(time.Hour)
+
+			err = db.UpdateTask(task)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tasks, err := db.GetTasks()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []int
+			for _, v := range tasks {
+				ids = append(ids, v.ID)
+			}
+
+			if !slices.Equal(ids, []int{1, 2, 5, 6}) {
+				t.Errorf("expected task IDs 1, 2, 5 and 6, but got: %v", ids)
+			}
+
+			if tasks[0].Status() != models.TaskDone {
+				t.Errorf("expected the first task to be done, but got: %s", tasks[0].Status())
+			}
+
+			_, err = db.GetTask(3)
+			if !errors.Is(err, errTaskNotFound) {
+				t.Errorf("expected error to be: %v, but got: %v", errTaskNotFound, err)
+			}
+		})
+	}
+}
+
+// TestTagEstimates checks that every backend keeps the history of tag
+// estimates in chronological order.
+func TestTagEstimates(t *testing.T) {
+	for _, name := range Backends() {
+		t.Run(name, func(t *testing.T) {
+			db := openTestBackend(t, name)
+
+			later := testStart.Add(time.Hour)
+
+			err := db.BatchUpdate(func(b Batch) error {
+				for _, v := range []*models.TagEstimate{
+					{StartTime: later, Tag: "writing", Estimate: 4},
+					{StartTime: testStart, Tag: "writing", Estimate: 6},
+					{StartTime: testStart, Tag: "design", Estimate: 2},
+				} {
+					err := b.PutTagEstimate(v)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			estimates, err := db.GetTagEstimates()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int
+			for _, v := range estimates {
+				got = append(got, v.Estimate)
+			}
+
+			if !slices.Equal(got, []int{2, 6, 4}) {
+				t.Errorf("expected estimates 2, 6 and 4, but got: %v", got)
+			}
+		})
+	}
+}
+
+func TestJSONLLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.jsonl")
+
+	j, err := NewJSONL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewJSONL(path)
+	if !errors.Is(err, errFocusRunning) {
+		t.Errorf("expected the database to be locked while it is open, but got: %v", err)
+	}
+
+	j.Close()
+
+	other, err := NewJSONL(path)
+	if err != nil {
+		t.Fatalf("expected the lock to be released on close, but got: %v", err)
+	}
+
+	other.Close()
+}
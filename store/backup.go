@@ -0,0 +1,321 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// autoBackupTag identifies automatic backups so that only those are
+	// removed when rotating backups.
+	autoBackupTag = "auto"
+
+	backupDayFormat  = "2006-01-02"
+	backupTimeFormat = "20060102T150405"
+
+	// compactTxMaxSize is the number of bytes copied in each transaction
+	// when compacting the database.
+	compactTxMaxSize = 64 * 1024
+)
+
+var (
+	errCorruptDB = errors.New("the database failed the integrity check")
+
+	errNotFocusDB = errors.New("the file is not a Focus database")
+)
+
+// backupName returns the file name of a backup of the database at dbFilePath.
+func backupName(dbFilePath, suffix string) string {
+	base := strings.TrimSuffix(
+		filepath.Base(dbFilePath),
+		filepath.Ext(dbFilePath),
+	)
+
+	return fmt.Sprintf("%s-%s.db", base, suffix)
+}
+
+// BackupPath returns the path of a new backup of the database at dbFilePath
+// in the specified directory.
+func BackupPath(dbFilePath, dir string) string {
+	return filepath.Join(
+		dir,
+		backupName(dbFilePath, time.Now().Format(backupTimeFormat)),
+	)
+}
+
+// Backup writes a consistent snapshot of the database to w without blocking
+// other readers or writers.
+func (c *Client) Backup(w io.Writer) (int64, error) {
+	var n int64
+
+	err := c.View(func(tx *bolt.Tx) error {
+		var err error
+
+		n, err = tx.WriteTo(w)
+
+		return err
+	})
+
+	return n, err
+}
+
+// BackupFile writes a snapshot of the database to the specified path. The
+// snapshot is written to a temporary file first so that an interrupted
+// backup never replaces a good one.
+func (c *Client) BackupFile(path string) (int64, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp := path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := c.Backup(f)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+		return 0, err
+	}
+
+	return n, os.Rename(tmp, path)
+}
+
+// AutoBackup creates the automatic backup for the current day in dir if it
+// does not exist already, and removes the oldest automatic backups so that at
+// most keep of them remain. Nothing is done if keep is zero.
+func (c *Client) AutoBackup(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	path := filepath.Join(
+		dir,
+		backupName(
+			c.Path(),
+			autoBackupTag+"-"+time.Now().Format(backupDayFormat),
+		),
+	)
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		_, err = c.BackupFile(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	backups, err := filepath.Glob(
+		filepath.Join(dir, backupName(c.Path(), autoBackupTag+"-*")),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the names sort in chronological order
+	slices.Sort(backups)
+
+	for len(backups) > keep {
+		err = os.Remove(backups[0])
+		if err != nil {
+			return err
+		}
+
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// Verify checks the integrity of the database at the specified path and
+// confirms that it can be opened by this version of Focus.
+func Verify(path string) error {
+	db, err := bolt.Open(
+		path,
+		0o600,
+		&bolt.Options{Timeout: 1 * time.Second, ReadOnly: true},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errCorruptDB, err)
+	}
+
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		var checkErr error
+
+		// the channel must be drained for the check to finish
+		for err := range tx.Check() {
+			if checkErr == nil {
+				checkErr = fmt.Errorf("%w: %w", errCorruptDB, err)
+			}
+		}
+
+		if checkErr != nil {
+			return checkErr
+		}
+
+		if tx.Bucket([]byte(sessionBucket)) == nil {
+			return errNotFocusDB
+		}
+
+		version, err := schemaVersion(tx)
+		if err != nil {
+			return err
+		}
+
+		if version > latestSchemaVersion() {
+			return errSchemaTooNew
+		}
+
+		return nil
+	})
+}
+
+// Restore replaces the database at dbFilePath with the specified backup once
+// its integrity has been verified. The current database is saved to backupDir
+// first, and the path to that copy is returned. Backups in an older format are
+// migrated the next time the database is opened.
+func Restore(dbFilePath, backup, backupDir string) (string, error) {
+	err := Verify(backup)
+	if err != nil {
+		return "", err
+	}
+
+	db, err := openDB(dbFilePath, false)
+	if err != nil {
+		return "", err
+	}
+
+	c := &Client{DB: db, path: dbFilePath}
+
+	// the lock on the current database is held until it is replaced
+	defer c.Close()
+
+	previous := filepath.Join(
+		backupDir,
+		backupName(dbFilePath, "pre-restore-"+time.Now().Format(backupTimeFormat)),
+	)
+
+	_, err = c.BackupFile(previous)
+	if err != nil {
+		return "", err
+	}
+
+	tmp := dbFilePath + ".restore"
+
+	err = copyFile(backup, tmp)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return previous, os.Rename(tmp, dbFilePath)
+}
+
+// Compact rewrites the database at dbFilePath to reclaim the space left by
+// deleted data. It returns the size of the file before and after compaction,
+// which are equal if there was nothing to reclaim.
+func Compact(dbFilePath string) (before, after int64, err error) {
+	src, err := openDB(dbFilePath, false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer src.Close()
+
+	tmp := dbFilePath + ".compact"
+
+	_ = os.Remove(tmp)
+
+	dst, err := bolt.Open(tmp, 0o600, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = bolt.Compact(dst, src, compactTxMaxSize)
+
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+		return 0, 0, err
+	}
+
+	srcInfo, err := os.Stat(dbFilePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dstInfo, err := os.Stat(tmp)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// small databases can grow when rewritten, so the original is kept
+	// unless space is actually reclaimed
+	if dstInfo.Size() >= srcInfo.Size() {
+		return srcInfo.Size(), srcInfo.Size(), os.Remove(tmp)
+	}
+
+	err = src.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return srcInfo.Size(), dstInfo.Size(), os.Rename(tmp, dbFilePath)
+}
+
+// copyFile copies the file at src to dst and syncs it to disk.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(dst)
+	}
+
+	return err
+}
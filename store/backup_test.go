@@ -0,0 +1,212 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+func TestAutoBackup(t *testing.T) {
+	c := newTestClient(t)
+
+	dir := t.TempDir()
+
+	// backups from previous days, and a manual backup which must be kept
+	existing := []string{
+		"focus-auto-2024-03-01.db",
+		"focus-auto-2024-03-02.db",
+		"focus-auto-2024-03-03.db",
+		"focus-20240301T090000.db",
+	}
+
+	for _, v := range existing {
+		err := os.WriteFile(filepath.Join(dir, v), nil, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := c.AutoBackup(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	today := "focus-auto-" + time.Now().Format(backupDayFormat) + ".db"
+
+	for _, v := range []string{today, existing[2], existing[3]} {
+		if _, err := os.Stat(filepath.Join(dir, v)); err != nil {
+			t.Errorf("expected backup %s to exist: %v", v, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 3 {
+		t.Errorf("expected 3 backups, but got: %d", len(entries))
+	}
+}
+
+func TestRestore(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(3)
+
+	m := make(map[time.Time]*models.Session)
+	for _, v := range sessions {
+		m[v.StartTime] = v
+	}
+
+	err := c.UpdateSessions(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	backup := filepath.Join(dir, "backup.db")
+
+	_, err = c.BackupFile(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteSessions([]time.Time{sessions[0].StartTime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := c.Path()
+	c.Close()
+
+	previous, err := Restore(path, backup, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(previous); err != nil {
+		t.Errorf("expected the previous database to be saved: %v", err)
+	}
+
+	c, err = NewClient(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	if got := getAllSessions(t, c); len(got) != len(sessions) {
+		t.Errorf("expected %d sessions, but got: %d", len(sessions), len(got))
+	}
+}
+
+type VerifyTest struct {
+	Name     string
+	Setup    func(path string) error
+	Expected error
+}
+
+var verifyTestCases = []VerifyTest{
+	{
+		Name: "Not a database",
+		Setup: func(path string) error {
+			return os.WriteFile(path, []byte("not a database"), 0o600)
+		},
+		Expected: errCorruptDB,
+	},
+	{
+		Name: "Database without sessions",
+		Setup: func(path string) error {
+			db, err := bolt.Open(path, 0o600, nil)
+			if err != nil {
+				return err
+			}
+
+			return db.Close()
+		},
+		Expected: errNotFocusDB,
+	},
+	{
+		Name: "Focus database",
+		Setup: func(path string) error {
+			c, err := NewClient(path)
+			if err != nil {
+				return err
+			}
+
+			return c.Close()
+		},
+	},
+}
+
+func TestVerify(t *testing.T) {
+	for _, tc := range verifyTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "focus.db")
+
+			err := tc.Setup(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Verify(path)
+			if !errors.Is(err, tc.Expected) {
+				t.Errorf("expected error to be: %v, but got: %v", tc.Expected, err)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(2000)
+
+	m := make(map[time.Time]*models.Session)
+	keys := make([]time.Time, 0, len(sessions))
+
+	for _, v := range sessions {
+		m[v.StartTime] = v
+		keys = append(keys, v.StartTime)
+	}
+
+	err := c.UpdateSessions(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteSessions(keys[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := c.Path()
+	c.Close()
+
+	before, after, err := Compact(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if after >= before {
+		t.Errorf("expected database to shrink from %d bytes, but got: %d", before, after)
+	}
+
+	c, err = NewClient(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	if got := getAllSessions(t, c); len(got) != 1 {
+		t.Errorf("expected 1 session, but got: %d", len(got))
+	}
+}
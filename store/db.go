@@ -1,27 +1,83 @@
 package store
 
 import (
+	"cmp"
+	"slices"
 	"time"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
 )
 
+// Batch groups changes to sessions, timers, tasks, and tag estimates so that
+// they can be applied atomically through DB.BatchUpdate.
+type Batch interface {
+	// PutSession creates or overwrites a session keyed by its start time
+	PutSession(sess *models.Session) error
+	// DeleteSession deletes a saved session along with its timer
+	DeleteSession(startTime time.Time) error
+	// PutTimer saves the state of an interrupted timer
+	PutTimer(timer *models.Timer) error
+	// DeleteTimer deletes the saved timer for the specified session
+	DeleteTimer(sessionKey time.Time) error
+	// PutTask creates or overwrites a task keyed by its ID. A task without
+	// an ID is assigned the next available one
+	PutTask(task *models.Task) error
+	// PutTagEstimate creates or overwrites a tag estimate keyed by its start
+	// time and tag
+	PutTagEstimate(est *models.TagEstimate) error
+}
+
 // DB is the database storage interface.
 type DB interface {
 	// GetSessions returns saved sessions according to the specified time and tag
-	// constraints
+	// constraints. Each matching session is returned once
 	GetSessions(
 		since, until time.Time,
-		tags []string,
+		tags config.TagQuery,
 	) ([]*models.Session, error)
-	// UpdateSessions updates one or more Focus sessions.
-	// Each session is created if it doesn't
-	// exist already, or overwritten if it does.
+	// UpdateSessions updates one or more Focus sessions in a single
+	// transaction. Each session is created if it doesn't exist already, or
+	// overwritten if it does. Either all the sessions are saved or none are.
 	UpdateSessions(map[time.Time]*models.Session) error
+	// BatchUpdate applies all the changes made through the provided Batch in a
+	// single transaction. None of the changes are applied if fn returns an
+	// error
+	BatchUpdate(fn func(b Batch) error) error
 	// DeleteSessions deletes one or more saved sessions
 	DeleteSessions(startTimes []time.Time) error
+	// GetSession retrieves a saved session by its start time
+	GetSession(startTime time.Time) (*models.Session, error)
+	// UpdateTimer saves the state of an interrupted timer so that it can be
+	// resumed later
+	UpdateTimer(timer *models.Timer) error
+	// RetrievePausedTimers returns all the interrupted timers whose sessions
+	// are still present in the database
+	RetrievePausedTimers() ([]*models.Timer, error)
+	// DeleteTimer deletes the saved timer for the specified session
+	DeleteTimer(sessionKey time.Time) error
+	// GetTasks returns all the saved tasks ordered by their ID
+	GetTasks() ([]*models.Task, error)
+	// GetTask retrieves a saved task by its ID
+	GetTask(id int) (*models.Task, error)
+	// UpdateTask creates or overwrites a task. A new task (without an ID) is
+	// assigned the next available ID
+	UpdateTask(task *models.Task) error
+	// GetTagEstimates returns all the saved tag estimates ordered by their
+	// start time
+	GetTagEstimates() ([]*models.TagEstimate, error)
 	// Close ends the database connection
 	Close() error
 	// Open initiates a database connection
 	Open() error
 }
+
+// sortTagEstimates orders tag estimates by their start time and tag.
+func sortTagEstimates(estimates []*models.TagEstimate) {
+	slices.SortFunc(estimates, func(a, b *models.TagEstimate) int {
+		return cmp.Or(
+			a.StartTime.Compare(b.StartTime),
+			cmp.Compare(a.Tag, b.Tag),
+		)
+	})
+}
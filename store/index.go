@@ -0,0 +1,326 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+const (
+	// tagIndexBucket contains a nested bucket for each tag which holds the
+	// keys of the sessions with that tag
+	tagIndexBucket = "tag_index"
+	// dayIndexBucket contains a nested bucket for each day which holds the
+	// keys of the sessions that were active on that day
+	dayIndexBucket = "day_index"
+	// indexedKey is set in the focus bucket once the indexes have been built
+	indexedKey = "indexed"
+
+	dayKeyFormat = "2006-01-02"
+)
+
+// sessionEnd returns the end time of a session, falling back to the end of its
+// last timeline segment for sessions that were not ended gracefully.
+func sessionEnd(sess *models.Session) time.Time {
+	if !sess.EndTime.IsZero() {
+		return sess.EndTime
+	}
+
+	if len(sess.Timeline) > 0 {
+		return sess.Timeline[len(sess.Timeline)-1].EndTime
+	}
+
+	return sess.StartTime
+}
+
+// sessionDays returns the index keys of the days that a session spans. Days
+// are computed in UTC so that lookups are not affected by offset changes.
+func sessionDays(sess *models.Session) [][]byte {
+	var days [][]byte
+
+	end := sessionEnd(sess)
+
+	for d := timeutil.RoundToStart(sess.StartTime.UTC()); !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, []byte(d.Format(dayKeyFormat)))
+	}
+
+	return days
+}
+
+// indexKeys returns the names of the index buckets that should contain the
+// session key.
+func indexKeys(sess *models.Session) map[string][][]byte {
+	var tags [][]byte
+
+	for _, tag := range sess.Tags {
+		if tag != "" {
+			tags = append(tags, []byte(tag))
+		}
+	}
+
+	return map[string][][]byte{
+		tagIndexBucket: tags,
+		dayIndexBucket: sessionDays(sess),
+	}
+}
+
+// indexSession adds the session key to the tag and day indexes.
+func indexSession(tx *bolt.Tx, key []byte, sess *models.Session) error {
+	for index, names := range indexKeys(sess) {
+		root, err := tx.CreateBucketIfNotExists([]byte(index))
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			b, err := root.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+
+			err = b.Put(key, []byte{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// unindexSession removes the session key from the tag and day indexes. Index
+// buckets that become empty are deleted.
+func unindexSession(tx *bolt.Tx, key []byte, sess *models.Session) error {
+	for index, names := range indexKeys(sess) {
+		root := tx.Bucket([]byte(index))
+		if root == nil {
+			continue
+		}
+
+		for _, name := range names {
+			b := root.Bucket(name)
+			if b == nil {
+				continue
+			}
+
+			err := b.Delete(key)
+			if err != nil {
+				return err
+			}
+
+			if k, _ := b.Cursor().First(); k == nil {
+				err = root.DeleteBucket(name)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// unindexExisting removes the session currently saved under key (if any) from
+// the indexes.
+func unindexExisting(tx *bolt.Tx, key []byte) error {
+	v := tx.Bucket([]byte(sessionBucket)).Get(key)
+	if v == nil {
+		return nil
+	}
+
+	var sess models.Session
+
+	err := json.Unmarshal(v, &sess)
+	if err != nil {
+		return err
+	}
+
+	return unindexSession(tx, key, &sess)
+}
+
+// rebuildIndexes discards the tag and day indexes and builds them again from
+// the saved sessions.
+func rebuildIndexes(tx *bolt.Tx) error {
+	slog.Info("building session indexes")
+
+	for _, index := range []string{tagIndexBucket, dayIndexBucket} {
+		if tx.Bucket([]byte(index)) == nil {
+			continue
+		}
+
+		err := tx.DeleteBucket([]byte(index))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, index := range []string{tagIndexBucket, dayIndexBucket} {
+		_, err := tx.CreateBucket([]byte(index))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tx.Bucket([]byte(sessionBucket)).ForEach(func(k, v []byte) error {
+		var sess models.Session
+
+		err := json.Unmarshal(v, &sess)
+		if err != nil {
+			return err
+		}
+
+		return indexSession(tx, k, &sess)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket([]byte(focusBucket)).Put([]byte(indexedKey), []byte(config.Version))
+}
+
+// hasIndexes reports whether the tag and day indexes have been built.
+func hasIndexes(tx *bolt.Tx) bool {
+	focus := tx.Bucket([]byte(focusBucket))
+
+	return focus != nil && focus.Get([]byte(indexedKey)) != nil
+}
+
+// requiredTags returns a tag from each group of the query that a session must
+// have to match the group. It returns false if any group has no such tag, in
+// which case the tag index cannot be used to find candidate sessions.
+func requiredTags(q config.TagQuery) ([]string, bool) {
+	if len(q) == 0 {
+		return nil, false
+	}
+
+	tags := make([]string, 0, len(q))
+
+	for _, group := range q {
+		i := slices.IndexFunc(group, func(t config.TagTerm) bool {
+			return !t.Negate
+		})
+
+		if i == -1 {
+			return nil, false
+		}
+
+		tags = append(tags, group[i].Tag)
+	}
+
+	return tags, true
+}
+
+// candidateKeys returns the sorted keys of the sessions that may match the
+// specified time and tag constraints.
+func candidateKeys(
+	tx *bolt.Tx,
+	since, until time.Time,
+	tags config.TagQuery,
+) [][]byte {
+	// the bounds are formatted like the keys so that sessions which started
+	// within the last second are not left out
+	minKey := timeutil.ToKey(since)
+	maxKey := timeutil.ToKey(until)
+
+	var sources []*bolt.Bucket
+
+	if required, ok := requiredTags(tags); ok {
+		tagIndex := tx.Bucket([]byte(tagIndexBucket))
+
+		for _, tag := range required {
+			if b := tagIndex.Bucket([]byte(tag)); b != nil {
+				sources = append(sources, b)
+			}
+		}
+	} else {
+		sources = append(sources, tx.Bucket([]byte(sessionBucket)))
+	}
+
+	var keys [][]byte
+
+	for _, b := range sources {
+		c := b.Cursor()
+
+		for k, _ := c.Seek(minKey); k != nil && bytes.Compare(k, maxKey) <= 0; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+	}
+
+	// sessions that started before the specified time may have been active
+	// within it
+	day := tx.Bucket([]byte(dayIndexBucket)).
+		Bucket([]byte(since.UTC().Format(dayKeyFormat)))
+	if day != nil {
+		_ = day.ForEach(func(k, _ []byte) error {
+			if bytes.Compare(k, minKey) < 0 {
+				keys = append(keys, k)
+			}
+
+			return nil
+		})
+	}
+
+	slices.SortFunc(keys, bytes.Compare)
+
+	return slices.CompactFunc(keys, bytes.Equal)
+}
+
+// indexedSessions uses the tag and day indexes to retrieve the sessions that
+// match the specified time and tag constraints. Only matching sessions are
+// decoded.
+func indexedSessions(
+	tx *bolt.Tx,
+	since, until time.Time,
+	tags config.TagQuery,
+) ([]*models.Session, error) {
+	var result []*models.Session
+
+	sessions := tx.Bucket([]byte(sessionBucket))
+	tagIndex := tx.Bucket([]byte(tagIndexBucket))
+
+	for _, k := range candidateKeys(tx, since, until, tags) {
+		matched := tags.MatchFunc(func(tag string) bool {
+			b := tagIndex.Bucket([]byte(tag))
+
+			return b != nil && b.Get(k) != nil
+		})
+		if !matched {
+			continue
+		}
+
+		v := sessions.Get(k)
+		if v == nil {
+			continue
+		}
+
+		var sess models.Session
+
+		err := json.Unmarshal(v, &sess)
+		if err != nil {
+			return nil, err
+		}
+
+		// include sessions that started earlier only if they were ended
+		// within the specified time period
+		if sess.StartTime.Before(since) && !sess.EndTime.After(since) {
+			continue
+		}
+
+		result = append(result, &sess)
+	}
+
+	return result, nil
+}
+
+// RebuildIndexes rebuilds the tag and day indexes from the saved sessions.
+func (c *Client) RebuildIndexes() error {
+	return c.Update(rebuildIndexes)
+}
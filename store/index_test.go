@@ -0,0 +1,271 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// indexBucketKeys returns the names of the nested buckets in the specified
+// index, and the session keys in each one.
+func indexBucketKeys(t *testing.T, c *Client, index string) map[string][]string {
+	t.Helper()
+
+	result := make(map[string][]string)
+
+	err := c.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(index)).ForEachBucket(func(name []byte) error {
+			return tx.Bucket([]byte(index)).Bucket(name).ForEach(func(k, _ []byte) error {
+				result[string(name)] = append(result[string(name)], string(k))
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(3)
+	sessions[0].Tags = []string{"reading", "writing"}
+
+	err := c.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions[0].Tags = []string{"reading"}
+
+	err = c.UpdateSessions(map[time.Time]*models.Session{
+		sessions[0].StartTime: sessions[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteSessions([]time.Time{sessions[1].StartTime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"reading": {
+			sessions[0].StartTime.Format(time.RFC3339Nano),
+			sessions[2].StartTime.Format(time.RFC3339Nano),
+		},
+	}
+
+	tags := indexBucketKeys(t, c, tagIndexBucket)
+	if len(tags) != len(expected) || !slices.Equal(tags["reading"], expected["reading"]) {
+		t.Errorf("expected tag index to be: %v, but got: %v", expected, tags)
+	}
+
+	days := indexBucketKeys(t, c, dayIndexBucket)
+	if got := days[testStart.Format(dayKeyFormat)]; !slices.Equal(got, expected["reading"]) {
+		t.Errorf("expected day index to be: %v, but got: %v", expected["reading"], got)
+	}
+}
+
+type IndexedSessionsTest struct {
+	Name  string
+	Since time.Time
+	Until time.Time
+	Query string
+}
+
+var indexedSessionsTestCases = []IndexedSessionsTest{
+	{
+		Name:  "All sessions",
+		Until: testStart.AddDate(0, 0, 3),
+	},
+	{
+		Name:  "Session started before the time period",
+		Since: testStart.Add(24*time.Hour + 10*time.Minute),
+		Until: testStart.AddDate(0, 0, 3),
+	},
+	{
+		Name:  "Sessions with a tag",
+		Until: testStart.AddDate(0, 0, 3),
+		Query: "writing",
+	},
+	{
+		Name:  "Sessions without a tag",
+		Since: testStart.Add(2 * time.Hour),
+		Until: testStart.AddDate(0, 0, 3),
+		Query: "!writing",
+	},
+	{
+		Name:  "Sessions with a combination of tags",
+		Until: testStart.AddDate(0, 0, 3),
+		Query: "writing+novel,reading+!writing",
+	},
+}
+
+func TestIndexedSessions(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(48)
+	for i, v := range sessions {
+		switch i % 3 {
+		case 0:
+			v.Tags = []string{"writing", "novel"}
+		case 1:
+			v.Tags = []string{"writing"}
+		}
+	}
+
+	err := c.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range indexedSessionsTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			q, err := config.ParseTagQuery(tc.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var indexed, scanned []*models.Session
+
+			err = c.View(func(tx *bolt.Tx) error {
+				indexed, err = indexedSessions(tx, tc.Since, tc.Until, q)
+				if err != nil {
+					return err
+				}
+
+				scanned, err = scanSessions(tx, tc.Since, tc.Until, q)
+
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(indexed) == 0 {
+				t.Fatal("expected sessions to be retrieved")
+			}
+
+			equal := slices.EqualFunc(indexed, scanned, func(a, b *models.Session) bool {
+				return a.StartTime.Equal(b.StartTime)
+			})
+			if !equal {
+				t.Errorf(
+					"expected indexed sessions to match scanned sessions: got %d, want %d",
+					len(indexed),
+					len(scanned),
+				)
+			}
+		})
+	}
+}
+
+// BenchmarkGetSessions compares retrieving sessions with and without the
+// indexes from a store containing 100k sessions.
+func BenchmarkGetSessions(b *testing.B) {
+	const (
+		count   = 100_000
+		tagSize = 20
+	)
+
+	c, err := NewClient(filepath.Join(b.TempDir(), "focus.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer c.Close()
+
+	start := time.Date(2020, time.January, 1, 8, 0, 0, 0, time.UTC)
+
+	err = c.BatchUpdate(func(batch Batch) error {
+		for i := range count {
+			// four sessions a day
+			s := start.Add(time.Duration(i/4)*24*time.Hour + time.Duration(i%4)*time.Hour)
+			e := s.Add(25 * time.Minute)
+
+			err := batch.PutSession(&models.Session{
+				Name:      config.Work,
+				StartTime: s,
+				EndTime:   e,
+				Duration:  25 * time.Minute,
+				Tags:      []string{fmt.Sprintf("tag%d", i%tagSize)},
+				Completed: true,
+				Timeline:  []models.SessionTimeline{{StartTime: s, EndTime: e}},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	end := start.AddDate(0, 0, count/4)
+	tagQuery, _ := config.ParseTagQuery("tag7")
+
+	queries := []struct {
+		name         string
+		since, until time.Time
+		tags         config.TagQuery
+	}{
+		{"tag", start, end, tagQuery},
+		{"week", end.AddDate(0, 0, -7), end, nil},
+	}
+
+	methods := []struct {
+		name string
+		fn   func(*bolt.Tx, time.Time, time.Time, config.TagQuery) ([]*models.Session, error)
+	}{
+		{"indexed", indexedSessions},
+		{"scan", scanSessions},
+	}
+
+	for _, q := range queries {
+		for _, m := range methods {
+			b.Run(q.name+"/"+m.name, func(b *testing.B) {
+				for range b.N {
+					err := c.View(func(tx *bolt.Tx) error {
+						_, err := m.fn(tx, q.since, q.until, q.tags)
+						return err
+					})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
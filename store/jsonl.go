@@ -0,0 +1,125 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// maxJSONLRecordSize is the size of the largest record that can be read from
+// a JSONL database.
+const maxJSONLRecordSize = 1024 * 1024
+
+var errInvalidJSONL = errors.New("invalid record in the JSONL database")
+
+// JSONL is a storage backend that appends every change to a JSON Lines file
+// and replays the file into memory when it is opened. Since the file is only
+// ever appended to, it can be kept in version control and synced between
+// machines. The file is locked while it is open so that commands which run
+// at the same time never append to a stale copy of it.
+type JSONL struct {
+	*Memory
+	file *os.File
+	path string
+}
+
+// NewJSONL opens the JSONL database at the specified path, creating it if
+// necessary.
+func NewJSONL(path string) (*JSONL, error) {
+	j := &JSONL{
+		path: path,
+	}
+
+	err := j.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// append writes the records of a batch to the end of the file in a single
+// write.
+func (j *JSONL) append(records []record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+
+	for _, r := range records {
+		err := enc.Encode(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := j.file.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return j.file.Sync()
+}
+
+func (j *JSONL) Open() error {
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = waitForLock(f, lockTimeout)
+	if err != nil {
+		f.Close()
+
+		if errors.Is(err, errLocked) {
+			return errFocusRunning
+		}
+
+		return err
+	}
+
+	mem := NewMemory()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxJSONLRecordSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var r record
+
+		err = json.Unmarshal(scanner.Bytes(), &r)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("%w: line %d: %w", errInvalidJSONL, line, err)
+		}
+
+		mem.apply(r)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	mem.commit = j.append
+
+	j.Memory = mem
+	j.file = f
+
+	return nil
+}
+
+// Close closes the file, which releases its lock.
+func (j *JSONL) Close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	// lockTimeout is how long a lock is waited for, in case it is briefly
+	// held by Running or by another command
+	lockTimeout = 1 * time.Second
+
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// errLocked is returned by lockFile if the file is locked by another process.
+var errLocked = errors.New("file is locked")
+
+// waitForLock takes an exclusive lock on f, retrying until the timeout elapses
+// if it is held by another process.
+func waitForLock(f *os.File, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := lockFile(f)
+		if !errors.Is(err, errLocked) || time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// lockInstance takes an exclusive lock on the file at the specified path,
+// retrying until the timeout elapses if the file is already locked.
+func lockInstance(path string, timeout time.Duration) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForLock(f, timeout)
+	if err != nil {
+		f.Close()
+
+		if errors.Is(err, errLocked) {
+			return nil, errFocusRunning
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// LockInstance takes an exclusive lock on the file at the specified path so
+// that only one timer can be active at a time. Since the timer doesn't hold
+// the database open, this lock is what prevents several timers from running
+// concurrently. The returned function releases the lock.
+func LockInstance(path string) (unlock func() error, err error) {
+	f, err := lockInstance(path, lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// closing the file releases the lock
+	return f.Close, nil
+}
+
+// Running reports whether a timer holds the lock on the file at the specified
+// path. It doesn't wait for the lock.
+func Running(path string) (bool, error) {
+	f, err := lockInstance(path, 0)
+	if err != nil {
+		if errors.Is(err, errFocusRunning) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return false, f.Close()
+}
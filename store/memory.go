@@ -0,0 +1,423 @@
+package store
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+const (
+	opPutSession    = "put_session"
+	opDeleteSession = "delete_session"
+	opPutTimer      = "put_timer"
+	opDeleteTimer   = "delete_timer"
+	opPutTask       = "put_task"
+	opPutEstimate   = "put_tag_estimate"
+)
+
+// record is a single change to the sessions, timers, tasks, or tag estimates.
+// Records are applied to the in-memory backend, and appended to the file of
+// the JSONL backend.
+type record struct {
+	Op      string          `json:"op"`
+	Key     time.Time       `json:"key"`
+	Session *models.Session `json:"session,omitempty"`
+	Timer   *models.Timer   `json:"timer,omitempty"`
+	Task    *models.Task    `json:"task,omitempty"`
+	// Estimate is keyed by both the record key and its tag
+	Estimate *models.TagEstimate `json:"tag_estimate,omitempty"`
+}
+
+// recordBatch collects the changes made in a batch so that they can be
+// applied together.
+type recordBatch struct {
+	records []record
+	// tasks are the tasks passed to PutTask in the same order as their
+	// records so that new tasks can be given their assigned IDs
+	tasks []*models.Task
+}
+
+// clone returns a deep copy of v so that callers never share state with the
+// store.
+func clone[T any](v *T) (*T, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var c T
+
+	err = json.Unmarshal(b, &c)
+
+	return &c, err
+}
+
+func (b *recordBatch) PutSession(sess *models.Session) error {
+	c, err := clone(sess)
+	if err != nil {
+		return err
+	}
+
+	b.records = append(b.records, record{
+		Op:      opPutSession,
+		Key:     sess.StartTime,
+		Session: c,
+	})
+
+	return nil
+}
+
+func (b *recordBatch) DeleteSession(startTime time.Time) error {
+	b.records = append(b.records, record{Op: opDeleteSession, Key: startTime})
+
+	return nil
+}
+
+func (b *recordBatch) PutTimer(timer *models.Timer) error {
+	c, err := clone(timer)
+	if err != nil {
+		return err
+	}
+
+	b.records = append(b.records, record{
+		Op:    opPutTimer,
+		Key:   timer.SessionKey,
+		Timer: c,
+	})
+
+	return nil
+}
+
+func (b *recordBatch) DeleteTimer(sessionKey time.Time) error {
+	b.records = append(b.records, record{Op: opDeleteTimer, Key: sessionKey})
+
+	return nil
+}
+
+func (b *recordBatch) PutTask(task *models.Task) error {
+	c, err := clone(task)
+	if err != nil {
+		return err
+	}
+
+	b.records = append(b.records, record{Op: opPutTask, Task: c})
+	b.tasks = append(b.tasks, task)
+
+	return nil
+}
+
+func (b *recordBatch) PutTagEstimate(est *models.TagEstimate) error {
+	c, err := clone(est)
+	if err != nil {
+		return err
+	}
+
+	b.records = append(b.records, record{
+		Op:       opPutEstimate,
+		Key:      est.StartTime,
+		Estimate: c,
+	})
+
+	return nil
+}
+
+// Memory is a storage backend that keeps sessions, timers, tasks, and tag
+// estimates in memory. It is useful in tests, and it holds the state of the
+// JSONL backend.
+type Memory struct {
+	sessions  map[string]*models.Session
+	timers    map[string]*models.Timer
+	tasks     map[int]*models.Task
+	estimates map[string]*models.TagEstimate
+	// commit is called with the records of each batch before they are
+	// applied. The batch is discarded if it returns an error
+	commit func(records []record) error
+	mu     sync.RWMutex
+}
+
+// NewMemory returns an empty in-memory storage backend.
+func NewMemory() *Memory {
+	return &Memory{
+		sessions:  make(map[string]*models.Session),
+		timers:    make(map[string]*models.Timer),
+		tasks:     make(map[int]*models.Task),
+		estimates: make(map[string]*models.TagEstimate),
+	}
+}
+
+// apply updates the in-memory state with the provided record.
+func (m *Memory) apply(r record) {
+	key := string(timeutil.ToKey(r.Key))
+
+	switch r.Op {
+	case opPutSession:
+		m.sessions[key] = r.Session
+	case opDeleteSession:
+		delete(m.sessions, key)
+		// a deleted session can no longer be resumed
+		delete(m.timers, key)
+	case opPutTimer:
+		m.timers[key] = r.Timer
+	case opDeleteTimer:
+		delete(m.timers, key)
+	case opPutTask:
+		m.tasks[r.Task.ID] = r.Task
+	case opPutEstimate:
+		m.estimates[key+"\x00"+r.Estimate.Tag] = r.Estimate
+	}
+}
+
+// assignTaskIDs gives the new tasks in a batch the next available IDs.
+func (m *Memory) assignTaskIDs(b *recordBatch) {
+	var last int
+
+	for id := range m.tasks {
+		last = max(last, id)
+	}
+
+	for _, r := range b.records {
+		if r.Task != nil {
+			last = max(last, r.Task.ID)
+		}
+	}
+
+	var i int
+
+	for _, r := range b.records {
+		if r.Op != opPutTask {
+			continue
+		}
+
+		if r.Task.ID == 0 {
+			last++
+			r.Task.ID = last
+			b.tasks[i].ID = last
+		}
+
+		i++
+	}
+}
+
+// write applies all the records of a batch, or none of them if they cannot be
+// committed.
+func (m *Memory) write(b *recordBatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assignTaskIDs(b)
+
+	if m.commit != nil {
+		err := m.commit(b.records)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, r := range b.records {
+		m.apply(r)
+	}
+
+	return nil
+}
+
+func (m *Memory) BatchUpdate(fn func(b Batch) error) error {
+	b := &recordBatch{}
+
+	err := fn(b)
+	if err != nil {
+		return err
+	}
+
+	return m.write(b)
+}
+
+func (m *Memory) UpdateSessions(sessions map[time.Time]*models.Session) error {
+	b := &recordBatch{}
+
+	for k, v := range sessions {
+		c, err := clone(v)
+		if err != nil {
+			return err
+		}
+
+		b.records = append(b.records, record{
+			Op:      opPutSession,
+			Key:     k,
+			Session: c,
+		})
+	}
+
+	return m.write(b)
+}
+
+func (m *Memory) DeleteSessions(startTimes []time.Time) error {
+	return m.BatchUpdate(func(b Batch) error {
+		for _, v := range startTimes {
+			err := b.DeleteSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *Memory) GetSession(startTime time.Time) (*models.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sess, ok := m.sessions[string(timeutil.ToKey(startTime))]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	return clone(sess)
+}
+
+func (m *Memory) GetSessions(
+	since, until time.Time,
+	tags config.TagQuery,
+) ([]*models.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*models.Session
+
+	for _, sess := range m.sessions {
+		if sess.StartTime.After(until) || !tags.Match(sess.Tags) {
+			continue
+		}
+
+		// include sessions that started earlier only if they were ended
+		// within the specified time period
+		if sess.StartTime.Before(since) && !sess.EndTime.After(since) {
+			continue
+		}
+
+		c, err := clone(sess)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	slices.SortFunc(result, func(a, b *models.Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return result, nil
+}
+
+func (m *Memory) UpdateTimer(timer *models.Timer) error {
+	return m.BatchUpdate(func(b Batch) error {
+		return b.PutTimer(timer)
+	})
+}
+
+func (m *Memory) RetrievePausedTimers() ([]*models.Timer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*models.Timer
+
+	for k, v := range m.timers {
+		// skip timers whose sessions have since been removed
+		if _, ok := m.sessions[k]; !ok {
+			continue
+		}
+
+		c, err := clone(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	slices.SortFunc(result, func(a, b *models.Timer) int {
+		return a.SessionKey.Compare(b.SessionKey)
+	})
+
+	return result, nil
+}
+
+func (m *Memory) DeleteTimer(sessionKey time.Time) error {
+	return m.BatchUpdate(func(b Batch) error {
+		return b.DeleteTimer(sessionKey)
+	})
+}
+
+func (m *Memory) GetTasks() ([]*models.Task, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*models.Task, 0, len(m.tasks))
+
+	for _, v := range m.tasks {
+		c, err := clone(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	slices.SortFunc(result, func(a, b *models.Task) int {
+		return a.ID - b.ID
+	})
+
+	return result, nil
+}
+
+func (m *Memory) GetTask(id int) (*models.Task, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, errTaskNotFound
+	}
+
+	return clone(task)
+}
+
+func (m *Memory) UpdateTask(task *models.Task) error {
+	return m.BatchUpdate(func(b Batch) error {
+		return b.PutTask(task)
+	})
+}
+
+func (m *Memory) GetTagEstimates() ([]*models.TagEstimate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*models.TagEstimate, 0, len(m.estimates))
+
+	for _, v := range m.estimates {
+		c, err := clone(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c)
+	}
+
+	sortTagEstimates(result)
+
+	return result, nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+func (m *Memory) Open() error {
+	return nil
+}
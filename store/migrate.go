@@ -1,22 +1,240 @@
 package store
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"go.etcd.io/bbolt"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
 )
 
+const (
+	// schemaVersionKey stores the version of the database schema in the focus
+	// bucket. It is independent of the application version.
+	schemaVersionKey = "schema_version"
+	// appVersionKey stores the version of Focus that last opened the database.
+	appVersionKey = "version"
+)
+
+var (
+	errMigrationDryRun = errors.New("migration dry run")
+
+	errSchemaTooNew = errors.New(
+		"the database was created by a newer version of Focus. Please upgrade to continue",
+	)
+)
+
+// Migration is a change to the database schema. Each migration upgrades the
+// database from the previous schema version to Version.
+type Migration struct {
+	migrate     func(tx *bbolt.Tx) error
+	Description string
+	Version     int
+}
+
+// migrations contains every schema change in the order they must be applied.
+// New migrations must be appended with the next version number.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "change session keys to RFC3339Nano and durations to nanoseconds",
+		migrate:     migrateV1_4_0,
+	},
+	{
+		Version:     2,
+		Description: "index sessions by tag and day",
+		migrate:     rebuildIndexes,
+	},
+	{
+		Version:     3,
+		Description: "add a bucket for tasks",
+		migrate:     createTaskBucket,
+	},
+	{
+		Version:     4,
+		Description: "add a bucket for tag estimates",
+		migrate:     createEstimateBucket,
+	},
+}
+
+// latestSchemaVersion returns the schema version produced by running all the
+// migrations.
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// schemaVersion returns the schema version of the database. Databases created
+// before schema versions were recorded are detected from the application
+// version. New databases have a version of zero so that they go through every
+// migration.
+func schemaVersion(tx *bbolt.Tx) (int, error) {
+	focus := tx.Bucket([]byte(focusBucket))
+
+	if focus != nil {
+		if v := focus.Get([]byte(schemaVersionKey)); v != nil {
+			return strconv.Atoi(string(v))
+		}
+
+		// v1.4.0 started recording the application version
+		if focus.Get([]byte(appVersionKey)) != nil {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// pendingMigrations returns the migrations that have not been applied to a
+// database with the specified schema version.
+func pendingMigrations(version int) []Migration {
+	var pending []Migration
+
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending
+}
+
+// backupPath returns the path of the backup made before upgrading the
+// database from the specified schema version.
+func backupPath(dbFilePath string, version int) string {
+	return fmt.Sprintf(
+		"%s.schema%d-%s.bak",
+		dbFilePath,
+		version,
+		time.Now().Format("20060102T150405"),
+	)
+}
+
+// migrate brings the database up to the latest schema version in a single
+// transaction. The database file is copied to a backup before any migration
+// is applied to an existing database. In dry-run mode, the migrations are run but not committed and no
+// backup is made. It returns the applied migrations and the backup path.
+func (c *Client) migrate(
+	dbFilePath string,
+	dryRun bool,
+) ([]Migration, string, error) {
+	var (
+		version int
+		isNew   bool
+		backup  string
+	)
+
+	err := c.View(func(tx *bbolt.Tx) error {
+		var err error
+
+		isNew = tx.Bucket([]byte(sessionBucket)) == nil
+		version, err = schemaVersion(tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if version > latestSchemaVersion() {
+		return nil, "", errSchemaTooNew
+	}
+
+	pending := pendingMigrations(version)
+
+	// there's nothing to back up in a new database
+	if len(pending) > 0 && !dryRun && !isNew {
+		backup = backupPath(dbFilePath, version)
+
+		err = c.View(func(tx *bbolt.Tx) error {
+			return tx.CopyFile(backup, 0o600)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	err = c.Update(func(tx *bbolt.Tx) error {
+		for _, name := range []string{sessionBucket, timerBucket, focusBucket} {
+			_, err := tx.CreateBucketIfNotExists([]byte(name))
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, m := range pending {
+			slog.Info(
+				"running db migration",
+				slog.Int("version", m.Version),
+				slog.String("description", m.Description),
+			)
+
+			err := m.migrate(tx)
+			if err != nil {
+				return fmt.Errorf("migration to schema version %d: %w", m.Version, err)
+			}
+		}
+
+		focus := tx.Bucket([]byte(focusBucket))
+
+		err := focus.Put(
+			[]byte(schemaVersionKey),
+			[]byte(strconv.Itoa(latestSchemaVersion())),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = focus.Put([]byte(appVersionKey), []byte(config.Version))
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errMigrationDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMigrationDryRun) {
+		return nil, "", err
+	}
+
+	return pending, backup, nil
+}
+
+// Migrate upgrades the database at the specified path to the latest schema
+// version and returns the migrations that were applied along with the path to
+// the backup of the original database. If dryRun is set, the migrations are
+// checked without changing the database.
+func Migrate(dbFilePath string, dryRun bool) ([]Migration, string, error) {
+	db, err := openDB(dbFilePath, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c := &Client{DB: db, path: dbFilePath}
+
+	defer c.Close()
+
+	return c.migrate(dbFilePath, dryRun)
+}
+
 // Change session key to RFC3339Nano and update duration to nanoseconds.
 func migrateSessionsV1_4_0(tx *bbolt.Tx) error {
 	bucket := tx.Bucket([]byte(sessionBucket))
 
-	cur := bucket.Cursor()
+	sessions := make(map[string]*models.Session)
 
-	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+	// the sessions are collected first since modifying the bucket while
+	// iterating over it invalidates the cursor
+	err := bucket.ForEach(func(k, v []byte) error {
 		var s models.Session
 
 		err := json.Unmarshal(v, &s)
@@ -24,22 +242,31 @@ func migrateSessionsV1_4_0(tx *bbolt.Tx) error {
 			return err
 		}
 
-		// s.Duration was in minutes, but must now be changed to nanoseconds
-		s.Duration *= time.Minute
+		sessions[string(k)] = &s
 
-		newKey := []byte(s.StartTime.Format(time.RFC3339Nano))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		err = cur.Delete()
+	for k := range sessions {
+		err = bucket.Delete([]byte(k))
 		if err != nil {
 			return err
 		}
+	}
+
+	for _, s := range sessions {
+		// s.Duration was in minutes, but must now be changed to nanoseconds
+		s.Duration *= time.Minute
 
 		b, err := json.Marshal(s)
 		if err != nil {
 			return err
 		}
 
-		err = bucket.Put(newKey, b)
+		err = bucket.Put([]byte(s.StartTime.Format(time.RFC3339Nano)), b)
 		if err != nil {
 			return err
 		}
@@ -51,13 +278,25 @@ func migrateSessionsV1_4_0(tx *bbolt.Tx) error {
 // Delete all exisiting timers as it won't be possible to resume paused sessions
 // after migrating the sessions.
 func migrateTimersV1_4_0(tx *bbolt.Tx) error {
-	timerBucket := "timers"
 	bucket := tx.Bucket([]byte(timerBucket))
 
-	cur := bucket.Cursor()
+	// new databases have no timers to delete
+	if bucket == nil {
+		return nil
+	}
+
+	var keys [][]byte
 
-	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
-		err := cur.Delete()
+	err := bucket.ForEach(func(k, _ []byte) error {
+		keys = append(keys, bytes.Clone(k))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		err = bucket.Delete(k)
 		if err != nil {
 			return err
 		}
@@ -66,11 +305,7 @@ func migrateTimersV1_4_0(tx *bbolt.Tx) error {
 	return nil
 }
 
-func (c *Client) migrateV1_4_0(tx *bbolt.Tx) error {
-	slog.Info(
-		"running db migrations to v1.4.0 format",
-	)
-
+func migrateV1_4_0(tx *bbolt.Tx) error {
 	err := migrateSessionsV1_4_0(tx)
 	if err != nil {
 		return err
@@ -78,3 +313,17 @@ func (c *Client) migrateV1_4_0(tx *bbolt.Tx) error {
 
 	return migrateTimersV1_4_0(tx)
 }
+
+// createTaskBucket adds the bucket where tasks are stored.
+func createTaskBucket(tx *bbolt.Tx) error {
+	_, err := tx.CreateBucketIfNotExists([]byte(taskBucket))
+
+	return err
+}
+
+// createEstimateBucket adds the bucket where tag estimates are stored.
+func createEstimateBucket(tx *bbolt.Tx) error {
+	_, err := tx.CreateBucketIfNotExists([]byte(estimateBucket))
+
+	return err
+}
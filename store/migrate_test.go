@@ -0,0 +1,187 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// copyFixture copies a database from the testdata directory so that it can be
+// modified by a test.
+func copyFixture(t *testing.T, name string) (path string, original []byte) {
+	t.Helper()
+
+	original, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path = filepath.Join(t.TempDir(), "focus.db")
+
+	err = os.WriteFile(path, original, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path, original
+}
+
+// backupSchemaVersion returns the schema version of a backup database.
+func backupSchemaVersion(t *testing.T, path string) int {
+	t.Helper()
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	var version int
+
+	err = db.View(func(tx *bolt.Tx) error {
+		version, err = schemaVersion(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return version
+}
+
+type MigrateTest struct {
+	Name    string
+	Fixture string
+	From    int
+	Applied int
+	Timers  int
+}
+
+// The fixtures contain three 25 minute work sessions: one tagged "writing",
+// one tagged "reading" and "novel", and an untagged one with a paused timer.
+var migrateTestCases = []MigrateTest{
+	{
+		Name:    "Upgrade database created before v1.4.0",
+		Fixture: "focus-v1.3.db",
+		From:    0,
+		Applied: 4,
+		Timers:  0,
+	},
+	{
+		Name:    "Upgrade database created by v1.4.0",
+		Fixture: "focus-v1.4.db",
+		From:    1,
+		Applied: 3,
+		Timers:  1,
+	},
+}
+
+func TestMigrate(t *testing.T) {
+	for _, tc := range migrateTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			path, _ := copyFixture(t, tc.Fixture)
+
+			applied, backup, err := Migrate(path, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(applied) != tc.Applied {
+				t.Errorf("expected %d migrations, but got: %d", tc.Applied, len(applied))
+			}
+
+			if got := backupSchemaVersion(t, backup); got != tc.From {
+				t.Errorf("expected backup to have schema version %d, but got: %d", tc.From, got)
+			}
+
+			c, err := NewClient(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer c.Close()
+
+			err = c.View(func(tx *bolt.Tx) error {
+				version, err := schemaVersion(tx)
+				if version != latestSchemaVersion() {
+					t.Errorf(
+						"expected schema version %d, but got: %d",
+						latestSchemaVersion(),
+						version,
+					)
+				}
+
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			q, _ := config.ParseTagQuery("novel")
+
+			sessions, err := c.GetSessions(time.Time{}, time.Now(), q)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sessions) != 1 || sessions[0].Duration != 25*time.Minute {
+				t.Errorf("expected one 25 minute session, but got: %v", sessions)
+			}
+
+			timers, err := c.RetrievePausedTimers()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(timers) != tc.Timers {
+				t.Errorf("expected %d timers, but got: %d", tc.Timers, len(timers))
+			}
+		})
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	path, original := copyFixture(t, "focus-v1.3.db")
+
+	applied, backup, err := Migrate(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != len(migrations) {
+		t.Errorf("expected %d migrations, but got: %d", len(migrations), len(applied))
+	}
+
+	if backup != "" {
+		t.Errorf("expected no backup in dry-run mode, but got: %s", backup)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(b, original) {
+		t.Error("expected database to be unchanged in dry-run mode")
+	}
+}
+
+func TestMigrationsOrder(t *testing.T) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf(
+				"expected migration %q to have version %d, but got: %d",
+				m.Description,
+				i+1,
+				m.Version,
+			)
+		}
+	}
+}
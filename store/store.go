@@ -3,10 +3,10 @@ package store
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"io/fs"
-	"slices"
 	"time"
 
 	bolt "go.etcd.io/bbolt"
@@ -20,40 +20,164 @@ import (
 // Client is a BoltDB database client.
 type Client struct {
 	*bolt.DB
+	// path is where the database file is located so that it can be
+	// reopened after it is closed
+	path string
+	// readOnly is set for clients that only read from the database. They
+	// share the file lock so that they can be used while a timer is active
+	readOnly bool
 }
 
 const (
-	sessionBucket = "sessions"
-	focusBucket   = "focus"
+	sessionBucket  = "sessions"
+	timerBucket    = "timers"
+	focusBucket    = "focus"
+	taskBucket     = "tasks"
+	estimateBucket = "tag_estimates"
 )
 
-var errFocusRunning = errors.New(
-	"is Focus already running? Only one instance can be active at a time",
+var (
+	errFocusRunning = errors.New(
+		"is Focus already running? Only one instance can be active at a time",
+	)
+
+	// ErrSessionNotFound is returned when a session is retrieved by a start
+	// time that no session has
+	ErrSessionNotFound = errors.New(
+		"the specified session does not exist",
+	)
+
+	errTaskNotFound = errors.New(
+		"the specified task does not exist",
+	)
 )
 
-func (c *Client) UpdateSessions(sessions map[time.Time]*models.Session) error {
-	for k, v := range sessions {
-		key := timeutil.ToKey(k)
+// boltBatch applies changes within a bolt read-write transaction.
+type boltBatch struct {
+	tx *bolt.Tx
+}
+
+func (b *boltBatch) putSession(key time.Time, sess *models.Session) error {
+	v, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	k := timeutil.ToKey(key)
+
+	err = unindexExisting(b.tx, k)
+	if err != nil {
+		return err
+	}
+
+	err = b.tx.Bucket([]byte(sessionBucket)).Put(k, v)
+	if err != nil {
+		return err
+	}
+
+	return indexSession(b.tx, k, sess)
+}
+
+func (b *boltBatch) PutSession(sess *models.Session) error {
+	return b.putSession(sess.StartTime, sess)
+}
+
+func (b *boltBatch) DeleteSession(startTime time.Time) error {
+	k := timeutil.ToKey(startTime)
+
+	err := unindexExisting(b.tx, k)
+	if err != nil {
+		return err
+	}
 
-		b, err := json.Marshal(v)
+	err = b.tx.Bucket([]byte(sessionBucket)).Delete(k)
+	if err != nil {
+		return err
+	}
+
+	// a deleted session can no longer be resumed
+	return b.DeleteTimer(startTime)
+}
+
+func (b *boltBatch) PutTimer(timer *models.Timer) error {
+	v, err := json.Marshal(timer)
+	if err != nil {
+		return err
+	}
+
+	return b.tx.Bucket([]byte(timerBucket)).
+		Put(timeutil.ToKey(timer.SessionKey), v)
+}
+
+func (b *boltBatch) DeleteTimer(sessionKey time.Time) error {
+	return b.tx.Bucket([]byte(timerBucket)).Delete(timeutil.ToKey(sessionKey))
+}
+
+// taskKey returns the key of a task in the tasks bucket. Keys are big-endian
+// so that tasks are ordered by their ID.
+func taskKey(id int) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(id))
+
+	return k
+}
+
+func (b *boltBatch) PutTask(task *models.Task) error {
+	bucket := b.tx.Bucket([]byte(taskBucket))
+
+	if task.ID == 0 {
+		id, err := bucket.NextSequence()
 		if err != nil {
 			return err
 		}
 
-		return c.Update(func(tx *bolt.Tx) error {
-			return tx.Bucket([]byte(sessionBucket)).Put(key, b)
-		})
+		task.ID = int(id)
+	} else if uint64(task.ID) > bucket.Sequence() {
+		// tasks copied from elsewhere keep their IDs, so new tasks must be
+		// numbered after them
+		err := bucket.SetSequence(uint64(task.ID))
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	v, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(taskKey(task.ID), v)
 }
 
-func (c *Client) DeleteSessions(startTimes []time.Time) error {
+// estimateKey returns the key of a tag estimate in the tag estimates bucket.
+func estimateKey(est *models.TagEstimate) []byte {
+	k := timeutil.ToKey(est.StartTime)
+	k = append(k, 0)
+
+	return append(k, est.Tag...)
+}
+
+func (b *boltBatch) PutTagEstimate(est *models.TagEstimate) error {
+	v, err := json.Marshal(est)
+	if err != nil {
+		return err
+	}
+
+	return b.tx.Bucket([]byte(estimateBucket)).Put(estimateKey(est), v)
+}
+
+func (c *Client) BatchUpdate(fn func(b Batch) error) error {
 	return c.Update(func(tx *bolt.Tx) error {
-		for i := range startTimes {
-			key := timeutil.ToKey(startTimes[i])
+		return fn(&boltBatch{tx})
+	})
+}
+
+func (c *Client) UpdateSessions(sessions map[time.Time]*models.Session) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		b := &boltBatch{tx}
 
-			err := tx.Bucket([]byte(sessionBucket)).Delete(key)
+		for k, v := range sessions {
+			err := b.putSession(k, v)
 			if err != nil {
 				return err
 			}
@@ -63,146 +187,316 @@ func (c *Client) DeleteSessions(startTimes []time.Time) error {
 	})
 }
 
-func (c *Client) Open() error {
-	db, err := openDB(config.DBFilePath())
+func (c *Client) DeleteSessions(startTimes []time.Time) error {
+	return c.BatchUpdate(func(b Batch) error {
+		for i := range startTimes {
+			err := b.DeleteSession(startTimes[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (c *Client) GetSession(startTime time.Time) (*models.Session, error) {
+	var sess models.Session
+
+	err := c.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(sessionBucket)).Get(timeutil.ToKey(startTime))
+		if b == nil {
+			return ErrSessionNotFound
+		}
+
+		return json.Unmarshal(b, &sess)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	*c = Client{
-		db,
-	}
+	return &sess, nil
+}
 
-	return nil
+func (c *Client) UpdateTimer(timer *models.Timer) error {
+	return c.BatchUpdate(func(b Batch) error {
+		return b.PutTimer(timer)
+	})
 }
 
-func (c *Client) GetSessions(
-	since, until time.Time,
-	tags []string,
-) ([]*models.Session, error) {
-	var result []*models.Session
+func (c *Client) RetrievePausedTimers() ([]*models.Timer, error) {
+	var result []*models.Timer
 
 	err := c.View(func(tx *bolt.Tx) error {
-		c := tx.Bucket([]byte(sessionBucket)).Cursor()
-		min := []byte(since.Format(time.RFC3339))
-		max := []byte(until.Format(time.RFC3339))
-
-		//nolint:ineffassign,staticcheck // due to how boltdb works
-		sk, sv := c.Seek(min)
-		// get the previous session so as to check if
-		// it was ended within the specified time bounds
-		pk, pv := c.Prev()
-		if pk != nil {
-			var sess models.Session
-
-			err := json.Unmarshal(pv, &sess)
+		sessions := tx.Bucket([]byte(sessionBucket))
+
+		return tx.Bucket([]byte(timerBucket)).ForEach(func(k, v []byte) error {
+			// skip timers whose sessions have since been removed
+			if sessions.Get(k) == nil {
+				return nil
+			}
+
+			var t models.Timer
+
+			err := json.Unmarshal(v, &t)
 			if err != nil {
 				return err
 			}
 
-			// include session in results if it was ended
-			// in the bounds of the specified time period
-			if sess.EndTime.After(since) {
-				sk, sv = pk, pv
-			} else {
-				sk, sv = c.Next()
+			result = append(result, &t)
+
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+func (c *Client) DeleteTimer(sessionKey time.Time) error {
+	return c.BatchUpdate(func(b Batch) error {
+		return b.DeleteTimer(sessionKey)
+	})
+}
+
+func (c *Client) GetTasks() ([]*models.Task, error) {
+	var result []*models.Task
+
+	err := c.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(taskBucket)).ForEach(func(_, v []byte) error {
+			var t models.Task
+
+			err := json.Unmarshal(v, &t)
+			if err != nil {
+				return err
 			}
-		} else {
-			sk, sv = c.Seek(min)
+
+			result = append(result, &t)
+
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+func (c *Client) GetTask(id int) (*models.Task, error) {
+	var task models.Task
+
+	err := c.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(taskBucket)).Get(taskKey(id))
+		if b == nil {
+			return errTaskNotFound
 		}
 
-		for k, v := sk, sv; k != nil && bytes.Compare(k, max) <= 0; k, v = c.Next() {
-			var sess models.Session
+		return json.Unmarshal(b, &task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (c *Client) UpdateTask(task *models.Task) error {
+	return c.BatchUpdate(func(b Batch) error {
+		return b.PutTask(task)
+	})
+}
+
+func (c *Client) GetTagEstimates() ([]*models.TagEstimate, error) {
+	var result []*models.TagEstimate
 
-			err := json.Unmarshal(v, &sess)
+	err := c.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(estimateBucket)).ForEach(func(_, v []byte) error {
+			var est models.TagEstimate
+
+			err := json.Unmarshal(v, &est)
 			if err != nil {
 				return err
 			}
 
-			// Filter out tags that don't match
-			if len(tags) != 0 {
-				for _, t := range sess.Tags {
-					if slices.Contains(tags, t) {
-						result = append(result, &sess)
-					}
-				}
-			} else {
-				result = append(result, &sess)
-			}
+			result = append(result, &est)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// keys don't sort chronologically across time zones
+	sortTagEstimates(result)
+
+	return result, nil
+}
+
+func (c *Client) Open() error {
+	db, err := openDB(c.path, c.readOnly)
+	if err != nil {
+		return err
+	}
+
+	c.DB = db
+
+	return nil
+}
+
+func (c *Client) GetSessions(
+	since, until time.Time,
+	tags config.TagQuery,
+) ([]*models.Session, error) {
+	var result []*models.Session
+
+	err := c.View(func(tx *bolt.Tx) error {
+		var err error
+
+		if hasIndexes(tx) {
+			result, err = indexedSessions(tx, since, until, tags)
+		} else {
+			result, err = scanSessions(tx, since, until, tags)
 		}
 
-		return nil
+		return err
 	})
 
 	return result, err
 }
 
-// openDB creates or opens a database.
-func openDB(dbFilePath string) (*bolt.DB, error) {
+// scanSessions retrieves sessions by decoding every session within the
+// specified time period. It is used when the indexes are not available.
+func scanSessions(
+	tx *bolt.Tx,
+	since, until time.Time,
+	tags config.TagQuery,
+) ([]*models.Session, error) {
+	var result []*models.Session
+
+	c := tx.Bucket([]byte(sessionBucket)).Cursor()
+	// the bounds are formatted like the keys so that sessions which started
+	// within the last second are not left out
+	min := timeutil.ToKey(since)
+	max := timeutil.ToKey(until)
+
+	//nolint:ineffassign,staticcheck // due to how boltdb works
+	sk, sv := c.Seek(min)
+	// get the previous session so as to check if
+	// it was ended within the specified time bounds
+	pk, pv := c.Prev()
+	if pk != nil {
+		var sess models.Session
+
+		err := json.Unmarshal(pv, &sess)
+		if err != nil {
+			return nil, err
+		}
+
+		// include session in results if it was ended
+		// in the bounds of the specified time period
+		if sess.EndTime.After(since) {
+			sk, sv = pk, pv
+		} else {
+			sk, sv = c.Next()
+		}
+	} else {
+		sk, sv = c.Seek(min)
+	}
+
+	for k, v := sk, sv; k != nil && bytes.Compare(k, max) <= 0; k, v = c.Next() {
+		var sess models.Session
+
+		err := json.Unmarshal(v, &sess)
+		if err != nil {
+			return nil, err
+		}
+
+		if tags.Match(sess.Tags) {
+			result = append(result, &sess)
+		}
+	}
+
+	return result, nil
+}
+
+// openDB creates or opens a database. A read-only database must already
+// exist.
+func openDB(dbFilePath string, readOnly bool) (*bolt.DB, error) {
 	var fileMode fs.FileMode = 0o600
 
 	db, err := bolt.Open(
 		dbFilePath,
 		fileMode,
-		&bolt.Options{Timeout: 1 * time.Second},
+		&bolt.Options{Timeout: 1 * time.Second, ReadOnly: readOnly},
 	)
 
-	if err != nil && errors.Is(err, bolterr.ErrTimeout) {
-		return nil, errFocusRunning
+	if err != nil {
+		if errors.Is(err, bolterr.ErrTimeout) {
+			return nil, errFocusRunning
+		}
+
+		return nil, err
 	}
 
 	return db, nil
 }
 
-// NewClient returns a wrapper to a BoltDB connection.
+// NewClient returns a wrapper to a BoltDB connection. The database is
+// migrated to the latest schema version if necessary.
 func NewClient(dbFilePath string) (*Client, error) {
-	db, err := openDB(dbFilePath)
+	db, err := openDB(dbFilePath, false)
 	if err != nil {
 		return nil, err
 	}
-	// Create the necessary buckets for storing data if they do not exist already
-	err = db.Update(func(tx *bolt.Tx) error {
-		_, err = tx.CreateBucketIfNotExists([]byte(sessionBucket))
-		if err != nil {
-			return err
-		}
 
-		_, err = tx.CreateBucketIfNotExists([]byte(focusBucket))
-		if err != nil {
-			return err
-		}
+	c := &Client{
+		DB:   db,
+		path: dbFilePath,
+	}
 
-		return nil
-	})
+	_, _, err = c.migrate(dbFilePath, false)
 	if err != nil {
+		c.Close()
 		return nil, err
 	}
 
-	c := &Client{
-		db,
-	}
-
-	err = db.Update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket([]byte(focusBucket))
-		version := string(bucket.Get([]byte("version")))
+	return c, nil
+}
 
-		// prior to v1.4.0, no version info was stored in the database
-		// if upgrading from earlier version, run migrations to meet
-		// current database format
-		// Does nothing for new users
-		if version == "" {
-			err = c.migrateV1_4_0(tx)
-			if err != nil {
-				return err
-			}
+// NewReadOnlyClient returns a client that can only read from the database, and
+// can be used while a timer is active. If the database doesn't exist yet or
+// needs to be migrated, a read-write client is returned instead.
+func NewReadOnlyClient(dbFilePath string) (*Client, error) {
+	db, err := openDB(dbFilePath, true)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NewClient(dbFilePath)
 		}
 
-		if version != config.Version {
-			return bucket.Put([]byte("version"), []byte(config.Version))
-		}
+		return nil, err
+	}
 
-		return nil
+	var version int
+
+	err = db.View(func(tx *bolt.Tx) error {
+		version, err = schemaVersion(tx)
+		return err
 	})
+	if err == nil && version > latestSchemaVersion() {
+		err = errSchemaTooNew
+	}
+
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if version < latestSchemaVersion() {
+		db.Close()
+		return NewClient(dbFilePath)
+	}
 
-	return c, err
+	return &Client{
+		DB:       db,
+		path:     dbFilePath,
+		readOnly: true,
+	}, nil
 }
@@ -0,0 +1,304 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+var testStart = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	c, err := NewClient(filepath.Join(t.TempDir(), "focus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		c.Close()
+	})
+
+	return c
+}
+
+func testSessions(n int) []*models.Session {
+	sessions := make([]*models.Session, n)
+
+	for i := range sessions {
+		start := testStart.Add(time.Duration(i) * time.Hour)
+		end := start.Add(25 * time.Minute)
+
+		sessions[i] = &models.Session{
+			Name:      config.Work,
+			StartTime: start,
+			EndTime:   end,
+			Duration:  25 * time.Minute,
+			Tags:      []string{"reading"},
+			Completed: true,
+			Timeline: []models.SessionTimeline{
+				{StartTime: start, EndTime: end},
+			},
+		}
+	}
+
+	return sessions
+}
+
+func getAllSessions(t *testing.T, c *Client) []*models.Session {
+	t.Helper()
+
+	sessions, err := c.GetSessions(time.Time{}, testStart.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sessions
+}
+
+type UpdateSessionsTest struct {
+	Name  string
+	Count int
+	Tags  []string
+}
+
+var updateSessionsTestCases = []UpdateSessionsTest{
+	{
+		Name:  "Edit a single session",
+		Count: 1,
+		Tags:  []string{"writing"},
+	},
+	{
+		Name:  "Edit multiple sessions",
+		Count: 5,
+		Tags:  []string{"writing", "novel"},
+	},
+}
+
+func TestUpdateSessions(t *testing.T) {
+	for _, tc := range updateSessionsTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := newTestClient(t)
+
+			sessions := testSessions(tc.Count)
+
+			m := make(map[time.Time]*models.Session)
+			for _, v := range sessions {
+				m[v.StartTime] = v
+			}
+
+			err := c.UpdateSessions(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range sessions {
+				v.Tags = tc.Tags
+			}
+
+			err = c.UpdateSessions(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := getAllSessions(t, c)
+
+			if len(got) != tc.Count {
+				t.Fatalf("expected %d sessions, but got: %d", tc.Count, len(got))
+			}
+
+			for _, v := range got {
+				if !slices.Equal(v.Tags, tc.Tags) {
+					t.Errorf(
+						"expected session %s to have tags: %v, but got: %v",
+						v.StartTime,
+						tc.Tags,
+						v.Tags,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchUpdate(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(3)
+
+	err := c.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+
+			err = b.PutTimer(&models.Timer{
+				SessionKey: v.StartTime,
+				PausedTime: v.EndTime,
+				WorkCycle:  1,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return b.DeleteSession(sessions[0].StartTime)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := getAllSessions(t, c); len(got) != 2 {
+		t.Errorf("expected 2 sessions, but got: %d", len(got))
+	}
+
+	timers, err := c.RetrievePausedTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(timers) != 2 {
+		t.Errorf("expected 2 timers, but got: %d", len(timers))
+	}
+}
+
+func TestBatchUpdateRollback(t *testing.T) {
+	c := newTestClient(t)
+
+	errAbort := errors.New("abort")
+
+	err := c.BatchUpdate(func(b Batch) error {
+		for _, v := range testSessions(3) {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected error to be: %v, but got: %v", errAbort, err)
+	}
+
+	if got := getAllSessions(t, c); len(got) != 0 {
+		t.Errorf("expected no sessions to be saved, but got: %d", len(got))
+	}
+}
+
+type GetSessionsTest struct {
+	Name     string
+	Query    string
+	Expected int
+}
+
+var getSessionsTestCases = []GetSessionsTest{
+	{
+		Name:     "Session matching several tags is returned once",
+		Query:    "reading,writing",
+		Expected: 3,
+	},
+	{
+		Name:     "All of the tags",
+		Query:    "reading+writing",
+		Expected: 1,
+	},
+	{
+		Name:     "Excluded tag",
+		Query:    "!writing",
+		Expected: 1,
+	},
+}
+
+func TestGetSessionsTags(t *testing.T) {
+	c := newTestClient(t)
+
+	sessions := testSessions(3)
+	sessions[0].Tags = []string{"reading", "writing"}
+	sessions[1].Tags = []string{"writing"}
+
+	err := c.BatchUpdate(func(b Batch) error {
+		for _, v := range sessions {
+			err := b.PutSession(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range getSessionsTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			q, err := config.ParseTagQuery(tc.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := c.GetSessions(time.Time{}, testStart.AddDate(0, 0, 1), q)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != tc.Expected {
+				t.Errorf("expected %d sessions, but got: %d", tc.Expected, len(got))
+			}
+		})
+	}
+}
+
+func TestGetSessionsSubSecond(t *testing.T) {
+	c := newTestClient(t)
+
+	// keys are formatted with the offset and fractional seconds of the start
+	// time
+	start := time.Date(2024, time.March, 4, 10, 0, 0, 123456789, time.FixedZone("WAT", 3600))
+
+	sess := testSessions(1)[0]
+	sess.StartTime = start
+	sess.EndTime = start.Add(25 * time.Minute)
+
+	err := c.BatchUpdate(func(b Batch) error {
+		return b.PutSession(sess)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.View(func(tx *bolt.Tx) error {
+		for name, get := range map[string]func(
+			tx *bolt.Tx,
+			since, until time.Time,
+			tags config.TagQuery,
+		) ([]*models.Session, error){
+			"indexed": indexedSessions,
+			"scan":    scanSessions,
+		} {
+			got, err := get(tx, start, start, nil)
+			if err != nil {
+				return err
+			}
+
+			if len(got) != 1 {
+				t.Errorf("%s: expected the session that started at the end of the range, but got %d sessions", name, len(got))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// Transient wraps a closed database connection, and opens it only for the
+// duration of each operation. This allows long-running processes such as the
+// timer to release the file lock between writes so that other commands can
+// access the database in the meantime.
+type Transient struct {
+	db DB
+}
+
+// NewTransient returns a wrapper that opens db for each operation. The
+// provided database must be closed.
+func NewTransient(db DB) *Transient {
+	return &Transient{db: db}
+}
+
+// withDB runs fn with the database opened, and closes it afterwards.
+func withDB[T any](db DB, fn func() (T, error)) (result T, err error) {
+	err = db.Open()
+	if err != nil {
+		return result, err
+	}
+
+	defer func() {
+		cerr := db.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	return fn()
+}
+
+// do runs fn with the database opened for operations that don't return a
+// value.
+func (t *Transient) do(fn func() error) error {
+	_, err := withDB(t.db, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+
+	return err
+}
+
+func (t *Transient) GetSessions(
+	since, until time.Time,
+	tags config.TagQuery,
+) ([]*models.Session, error) {
+	return withDB(t.db, func() ([]*models.Session, error) {
+		return t.db.GetSessions(since, until, tags)
+	})
+}
+
+func (t *Transient) UpdateSessions(sessions map[time.Time]*models.Session) error {
+	return t.do(func() error {
+		return t.db.UpdateSessions(sessions)
+	})
+}
+
+func (t *Transient) BatchUpdate(fn func(b Batch) error) error {
+	return t.do(func() error {
+		return t.db.BatchUpdate(fn)
+	})
+}
+
+func (t *Transient) DeleteSessions(startTimes []time.Time) error {
+	return t.do(func() error {
+		return t.db.DeleteSessions(startTimes)
+	})
+}
+
+func (t *Transient) GetSession(startTime time.Time) (*models.Session, error) {
+	return withDB(t.db, func() (*models.Session, error) {
+		return t.db.GetSession(startTime)
+	})
+}
+
+func (t *Transient) UpdateTimer(timer *models.Timer) error {
+	return t.do(func() error {
+		return t.db.UpdateTimer(timer)
+	})
+}
+
+func (t *Transient) RetrievePausedTimers() ([]*models.Timer, error) {
+	return withDB(t.db, func() ([]*models.Timer, error) {
+		return t.db.RetrievePausedTimers()
+	})
+}
+
+func (t *Transient) DeleteTimer(sessionKey time.Time) error {
+	return t.do(func() error {
+		return t.db.DeleteTimer(sessionKey)
+	})
+}
+
+func (t *Transient) GetTasks() ([]*models.Task, error) {
+	return withDB(t.db, func() ([]*models.Task, error) {
+		return t.db.GetTasks()
+	})
+}
+
+func (t *Transient) GetTask(id int) (*models.Task, error) {
+	return withDB(t.db, func() (*models.Task, error) {
+		return t.db.GetTask(id)
+	})
+}
+
+func (t *Transient) UpdateTask(task *models.Task) error {
+	return t.do(func() error {
+		return t.db.UpdateTask(task)
+	})
+}
+
+func (t *Transient) GetTagEstimates() ([]*models.TagEstimate, error) {
+	return withDB(t.db, func() ([]*models.TagEstimate, error) {
+		return t.db.GetTagEstimates()
+	})
+}
+
+// Close is a no-op since the database is closed after each operation.
+func (t *Transient) Close() error {
+	return nil
+}
+
+// Open is a no-op since the database is opened for each operation.
+func (t *Transient) Open() error {
+	return nil
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolterr "go.etcd.io/bbolt/errors"
+)
+
+// TestTransientReadOnly checks that the database can be read while it is
+// used by a transient connection, as is the case when a timer is active.
+func TestTransientReadOnly(t *testing.T) {
+	c := newTestClient(t)
+
+	path := c.Path()
+
+	err := c.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := NewTransient(c)
+
+	sessions := testSessions(3)
+
+	putSessions(t, db, sessions[:2])
+
+	ro, err := NewReadOnlyClient(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := getAllSessions(t, ro); len(got) != 2 {
+		t.Errorf("expected 2 sessions, but got: %d", len(got))
+	}
+
+	err = ro.DeleteSessions([]time.Time{sessions[0].StartTime})
+	if !errors.Is(err, bolterr.ErrDatabaseReadOnly) {
+		t.Errorf("expected error to be: %v, but got: %v", bolterr.ErrDatabaseReadOnly, err)
+	}
+
+	err = ro.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	putSessions(t, db, sessions[2:])
+
+	got, err := db.GetSessions(testStart, testStart.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 3 {
+		t.Errorf("expected 3 sessions, but got: %d", len(got))
+	}
+}
+
+func TestLockInstance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.lock")
+
+	unlock, err := LockInstance(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = LockInstance(path)
+	if !errors.Is(err, errFocusRunning) {
+		t.Errorf("expected error to be: %v, but got: %v", errFocusRunning, err)
+	}
+
+	running, err := Running(path)
+	if err != nil || !running {
+		t.Errorf("expected a timer to be running, but got: %t, %v", running, err)
+	}
+
+	err = unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	running, err = Running(path)
+	if err != nil || running {
+		t.Errorf("expected no timer to be running, but got: %t, %v", running, err)
+	}
+
+	unlock, err = LockInstance(path)
+	if err != nil {
+		t.Fatalf("expected the lock to be released: %v", err)
+	}
+
+	unlock()
+}
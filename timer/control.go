@@ -0,0 +1,300 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/report"
+)
+
+// Commands accepted by the timer through the control socket.
+const (
+	CtlStatus   = "status"
+	CtlPause    = "pause"
+	CtlResume   = "resume"
+	CtlSkip     = "skip"
+	CtlStop     = "stop"
+	CtlAddTag   = "add-tag"
+	CtlSetSound = "set-sound"
+)
+
+// controlTimeout is how long a client waits for the timer to handle a
+// command.
+const controlTimeout = 5 * time.Second
+
+type (
+	// ControlRequest is a command sent to the active timer through the
+	// control socket.
+	ControlRequest struct {
+		Command string   `json:"command"`
+		Args    []string `json:"args,omitempty"`
+	}
+
+	// ControlResponse is the reply of the active timer to a ControlRequest.
+	ControlResponse struct {
+		Status *report.Status `json:"status,omitempty"`
+		Error  string         `json:"error,omitempty"`
+		OK     bool           `json:"ok"`
+	}
+
+	// controlMsg delivers a ControlRequest to the bubbletea program so that
+	// it is handled alongside key presses and clock ticks.
+	controlMsg struct {
+		req   ControlRequest
+		reply chan ControlResponse
+	}
+)
+
+// Listen accepts commands for the timer on the Unix socket at path, and
+// delivers them to p. The caller must hold the instance lock, and should close
+// the returned listener when the timer exits.
+func (t *Timer) Listen(p *tea.Program, path string) (net.Listener, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	// a socket left behind by a timer that didn't exit cleanly
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	go serve(ln, p)
+
+	return ln, nil
+}
+
+// serve handles connections to the control socket until it is closed.
+func serve(ln net.Listener, p *tea.Program) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			slog.Error("control socket", slog.Any("error", err))
+
+			continue
+		}
+
+		go handleConn(conn, p)
+	}
+}
+
+// handleConn reads a single request from conn and writes the timer's response.
+func handleConn(conn net.Conn, p *tea.Program) {
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(controlTimeout))
+
+	var (
+		req  ControlRequest
+		resp ControlResponse
+	)
+
+	err := json.NewDecoder(conn).Decode(&req)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		reply := make(chan ControlResponse, 1)
+
+		p.Send(controlMsg{req: req, reply: reply})
+
+		select {
+		case resp = <-reply:
+		case <-time.After(controlTimeout):
+			resp.Error = errControlTimeout.Error()
+		}
+	}
+
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// paused reports whether the current session has started and is paused.
+func (t *Timer) paused() bool {
+	return !t.waitForNextSession && !t.clock.Running() && !t.clock.Timedout()
+}
+
+// control handles a command received through the control socket in the same
+// way as the equivalent key press.
+func (t *Timer) control(req ControlRequest) (ControlResponse, tea.Cmd) {
+	var (
+		cmd tea.Cmd
+		err error
+	)
+
+	switch req.Command {
+	case CtlStatus:
+	case CtlPause:
+		cmd, err = t.controlPause()
+	case CtlResume:
+		cmd, err = t.controlResume()
+	case CtlSkip:
+		if t.Current.Name == config.Work || t.waitForNextSession {
+			err = errSkipNotBreak
+			break
+		}
+
+		if t.Opts.Strict {
+			err = errSkipStrict
+			break
+		}
+
+		cmd = t.skipSession()
+	case CtlStop:
+		cmd = t.quit()
+	case CtlAddTag:
+		cmd, err = t.addTags(req.Args)
+	case CtlSetSound:
+		err = t.setSound(req.Args)
+	default:
+		err = errUnknownCommand
+	}
+
+	if err != nil {
+		return ControlResponse{Error: err.Error()}, nil
+	}
+
+	s := t.status()
+
+	return ControlResponse{OK: true, Status: &s}, cmd
+}
+
+func (t *Timer) controlPause() (tea.Cmd, error) {
+	if t.Current.Name != config.Work {
+		return nil, errPauseBreak
+	}
+
+	if t.Opts.Strict {
+		return nil, errPauseStrict
+	}
+
+	if !t.clock.Running() {
+		return nil, errNotRunning
+	}
+
+	return t.clock.Toggle(), nil
+}
+
+func (t *Timer) controlResume() (tea.Cmd, error) {
+	if t.waitForNextSession {
+		return t.startNextSession(), nil
+	}
+
+	if !t.paused() {
+		return nil, errNotPaused
+	}
+
+	return t.clock.Toggle(), nil
+}
+
+// addTags adds the provided tags to the current session and the sessions that
+// follow.
+func (t *Timer) addTags(args []string) (tea.Cmd, error) {
+	tags := slices.Clone(t.Opts.Tags)
+
+	for _, arg := range args {
+		for _, tag := range strings.Split(arg, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	if len(tags) == len(t.Opts.Tags) {
+		return nil, errNoNewTags
+	}
+
+	t.Opts.Tags = tags
+	t.Current.Tags = tags
+
+	err := t.loadEstimates()
+	if err != nil {
+		slog.Error("unable to compute tag estimates", slog.Any("error", err))
+	}
+
+	_ = t.writeStatusFile()
+
+	if t.waitForNextSession {
+		return nil, nil
+	}
+
+	return t.persist(), nil
+}
+
+// setSound changes the ambient sound, or turns it off.
+func (t *Timer) setSound(args []string) error {
+	if len(args) != 1 {
+		return errNoSound
+	}
+
+	prev := t.Opts.AmbientSound
+
+	t.Opts.AmbientSound = args[0]
+	if args[0] == config.SoundOff {
+		t.Opts.AmbientSound = ""
+	}
+
+	err := t.setAmbientSound()
+	if err != nil {
+		t.Opts.AmbientSound = prev
+		return err
+	}
+
+	return nil
+}
+
+// Control sends a command to the timer listening on the control socket at
+// path, and returns the status of the timer after the command is handled.
+func Control(path string, req ControlRequest) (*report.Status, error) {
+	conn, err := net.DialTimeout("unix", path, controlTimeout)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) ||
+			errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, errTimerNotRunning
+		}
+
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(controlTimeout))
+
+	err = json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ControlResponse
+
+	err = json.NewDecoder(conn).Decode(&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.OK {
+		return nil, errors.New(resp.Error)
+	}
+
+	return resp.Status, nil
+}
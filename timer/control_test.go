@@ -0,0 +1,145 @@
+package timer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	btimer "github.com/charmbracelet/bubbles/timer"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type ControlTest struct {
+	Name     string
+	Session  config.SessType
+	Request  ControlRequest
+	Expected error
+	Tags     []string
+	Strict   bool
+}
+
+var controlTestCases = []ControlTest{
+	{
+		Name:    "Status",
+		Session: config.Work,
+		Request: ControlRequest{Command: CtlStatus},
+		Tags:    []string{"writing"},
+	},
+	{
+		Name:     "Pause in strict mode",
+		Session:  config.Work,
+		Request:  ControlRequest{Command: CtlPause},
+		Strict:   true,
+		Expected: errPauseStrict,
+	},
+	{
+		Name:     "Pause a break session",
+		Session:  config.ShortBreak,
+		Request:  ControlRequest{Command: CtlPause},
+		Expected: errPauseBreak,
+	},
+	{
+		Name:     "Skip a work session",
+		Session:  config.Work,
+		Request:  ControlRequest{Command: CtlSkip},
+		Expected: errSkipNotBreak,
+	},
+	{
+		Name:    "Add tags",
+		Session: config.Work,
+		Request: ControlRequest{
+			Command: CtlAddTag,
+			Args:    []string{"reading,writing", "code"},
+		},
+		Tags: []string{"writing", "reading", "code"},
+	},
+	{
+		Name:     "Add existing tags",
+		Session:  config.Work,
+		Request:  ControlRequest{Command: CtlAddTag, Args: []string{"writing"}},
+		Expected: errNoNewTags,
+	},
+	{
+		Name:     "Unknown command",
+		Session:  config.Work,
+		Request:  ControlRequest{Command: "rewind"},
+		Expected: errUnknownCommand,
+	},
+}
+
+func TestControl(t *testing.T) {
+	for _, tc := range controlTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			timer := &Timer{
+				db: store.NewMemory(),
+				Opts: &config.TimerConfig{
+					Duration: config.Duration{
+						config.Work:       25 * time.Minute,
+						config.ShortBreak: 5 * time.Minute,
+					},
+					Tags:   []string{"writing"},
+					Strict: tc.Strict,
+				},
+				clock: btimer.New(10 * time.Minute),
+			}
+
+			timer.Current = timer.newSession(timer.Opts.Step(tc.Session))
+
+			resp, _ := timer.control(tc.Request)
+
+			if tc.Expected != nil {
+				if resp.OK || resp.Error != tc.Expected.Error() {
+					t.Errorf("expected error to be: %v, but got: %s", tc.Expected, resp.Error)
+				}
+
+				return
+			}
+
+			if !resp.OK {
+				t.Fatalf("expected command to succeed, but got: %s", resp.Error)
+			}
+
+			if !slices.Equal(resp.Status.Tags, tc.Tags) {
+				t.Errorf("expected tags to be: %v, but got: %v", tc.Tags, resp.Status.Tags)
+			}
+		})
+	}
+}
+
+func TestControlNotRunning(t *testing.T) {
+	_, err := Control(t.TempDir()+"/focus.sock", ControlRequest{Command: CtlStatus})
+	if !errors.Is(err, errTimerNotRunning) {
+		t.Errorf("expected error to be: %v, but got: %v", errTimerNotRunning, err)
+	}
+}
+
+func TestStopMsg(t *testing.T) {
+	db := store.NewMemory()
+
+	timer := &Timer{
+		db: db,
+		Opts: &config.TimerConfig{
+			Duration: config.Duration{config.Work: 25 * time.Minute},
+		},
+		clock: btimer.New(10 * time.Minute),
+	}
+
+	timer.Current = timer.newSession(timer.Opts.Step(config.Work))
+
+	_, cmd := timer.Update(stopMsg{})
+	if cmd == nil {
+		t.Fatal("expected the timer to quit")
+	}
+
+	timers, err := db.RetrievePausedTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(timers) != 1 {
+		t.Errorf("expected the session to be saved so that it can be resumed, but got %d timers", len(timers))
+	}
+}
@@ -14,4 +14,56 @@ var (
 	errStrictMode = &apperr.Error{
 		Message: "session resumption failed: strict mode is enabled",
 	}
+
+	errNoPausedTimers = &apperr.Error{
+		Message: "there are no interrupted sessions to resume",
+	}
+
+	errInvalidSessionIndex = &apperr.Error{
+		Message: "invalid input: the session number does not match any interrupted session",
+	}
+
+	errTimerNotRunning = &apperr.Error{
+		Message: "no timer is running",
+	}
+
+	errControlTimeout = &apperr.Error{
+		Message: "the timer did not respond in time",
+	}
+
+	errUnknownCommand = &apperr.Error{
+		Message: "unknown command",
+	}
+
+	errNotRunning = &apperr.Error{
+		Message: "the session is not running",
+	}
+
+	errNotPaused = &apperr.Error{
+		Message: "the session is not paused",
+	}
+
+	errPauseBreak = &apperr.Error{
+		Message: "only work sessions can be paused",
+	}
+
+	errPauseStrict = &apperr.Error{
+		Message: "work sessions cannot be paused in strict mode",
+	}
+
+	errSkipNotBreak = &apperr.Error{
+		Message: "only running break sessions can be skipped",
+	}
+
+	errSkipStrict = &apperr.Error{
+		Message: "break sessions cannot be skipped in strict mode",
+	}
+
+	errNoNewTags = &apperr.Error{
+		Message: "please provide one or more tags that are not already applied",
+	}
+
+	errNoSound = &apperr.Error{
+		Message: "please provide a single ambient sound, or 'off' to disable it",
+	}
 )
@@ -0,0 +1,79 @@
+package timer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// tagProgress is the work completed towards the estimate for a tag.
+type tagProgress struct {
+	tag       string
+	estimate  int
+	completed int
+}
+
+// loadEstimates computes the work completed towards the current estimates of
+// the tags applied to the sessions.
+func (t *Timer) loadEstimates() error {
+	t.estimates = nil
+
+	if len(t.Opts.Tags) == 0 {
+		return nil
+	}
+
+	estimates, err := t.db.GetTagEstimates()
+	if err != nil {
+		return err
+	}
+
+	estimates = slices.DeleteFunc(
+		models.CurrentEstimates(estimates),
+		func(v *models.TagEstimate) bool {
+			return !slices.Contains(t.Opts.Tags, v.Tag)
+		},
+	)
+
+	if len(estimates) == 0 {
+		return nil
+	}
+
+	// estimates are sorted by their start time
+	sessions, err := t.db.GetSessions(estimates[0].StartTime, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range t.Opts.Tags {
+		i := slices.IndexFunc(estimates, func(v *models.TagEstimate) bool {
+			return v.Tag == tag
+		})
+		if i == -1 {
+			continue
+		}
+
+		t.estimates = append(t.estimates, tagProgress{
+			tag:       tag,
+			estimate:  estimates[i].Estimate,
+			completed: estimates[i].CountCompleted(sessions, time.Time{}),
+		})
+	}
+
+	return nil
+}
+
+// estimatesView describes the progress towards the estimates of the current
+// tags such as "tag writing: 3/6", or returns an empty string if none of the
+// tags has an estimate.
+func (t *Timer) estimatesView() string {
+	views := make([]string, len(t.estimates))
+
+	for i, v := range t.estimates {
+		views[i] = fmt.Sprintf("tag %s: %d/%d", v.tag, v.completed, v.estimate)
+	}
+
+	return strings.Join(views, " · ")
+}
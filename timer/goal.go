@@ -0,0 +1,209 @@
+package timer
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+// dailyProgress is the work recorded so far today.
+type dailyProgress struct {
+	// day is the start of the day that the progress is for
+	day time.Time
+	// sessions is the number of completed work sessions
+	sessions int
+	// worked is the time spent in work sessions, including interrupted ones
+	worked time.Duration
+}
+
+// reached reports whether the progress meets the specified goal.
+func (p dailyProgress) reached(goal config.DailyGoal) bool {
+	if goal.Sessions > 0 {
+		return p.sessions >= goal.Sessions
+	}
+
+	return goal.Duration > 0 && p.worked >= goal.Duration
+}
+
+// formatWorkTime formats a duration in hours and minutes (e.g. 2h15m).
+func formatWorkTime(d time.Duration) string {
+	hrs, mins := timeutil.MinsToHoursAndMins(int(d.Minutes()))
+
+	switch {
+	case hrs == 0:
+		return fmt.Sprintf("%dm", mins)
+	case mins == 0:
+		return fmt.Sprintf("%dh", hrs)
+	}
+
+	return fmt.Sprintf("%dh%dm", hrs, mins)
+}
+
+// loadProgress computes the work recorded today from the database. The
+// current session is left out since it is counted by addProgress once it ends.
+func (t *Timer) loadProgress() error {
+	now := time.Now()
+	day := timeutil.RoundToStart(now)
+
+	sessions, err := t.db.GetSessions(day, now, nil)
+	if err != nil {
+		return err
+	}
+
+	p := dailyProgress{day: day}
+
+	for _, sess := range sessions {
+		if sess.IsBreak() ||
+			t.Current != nil && sess.StartTime.Equal(t.Current.StartTime) {
+			continue
+		}
+
+		if sess.Completed {
+			p.sessions++
+		}
+
+		for _, v := range sess.Timeline {
+			p.worked += v.EndTime.Sub(v.StartTime)
+		}
+	}
+
+	t.today = p
+
+	return nil
+}
+
+// addProgress counts the current work session towards the progress for today
+// once it ends, without reading the recorded sessions from the database again.
+func (t *Timer) addProgress() {
+	sess := *t.Current
+	sess.Timeline = slices.Clone(sess.Timeline)
+
+	sess.UpdateEndTime(t.clock.Timedout())
+	sess.Normalise()
+
+	// the progress starts afresh when the timer runs past midnight
+	day := timeutil.RoundToStart(sess.EndTime)
+	if !day.Equal(t.today.day) {
+		t.today = dailyProgress{day: day}
+	}
+
+	if sess.StartTime.Before(day) {
+		return
+	}
+
+	if sess.Completed {
+		t.today.sessions++
+	}
+
+	for _, v := range sess.Timeline {
+		t.today.worked += v.EndTime.Sub(v.StartTime)
+	}
+}
+
+// recordWork counts a completed work session towards the session limit, the
+// daily goal, and the tag estimates, and reports whether the timer should exit
+// as a result. The
+// daily goal only ends the timer when it is reached during this run so that
+// work can continue once the goal has been met.
+func (t *Timer) recordWork() bool {
+	t.completed++
+
+	prev := t.today
+
+	if t.Opts.DailyGoal.IsSet() {
+		t.addProgress()
+	}
+
+	err := t.loadEstimates()
+	if err != nil {
+		slog.Error("unable to compute tag estimates", slog.Any("error", err))
+	}
+
+	switch {
+	case t.Opts.MaxSessions > 0 && t.completed >= t.Opts.MaxSessions:
+		t.summary = fmt.Sprintf(
+			"Session limit of %d reached",
+			t.Opts.MaxSessions,
+		)
+	case !prev.reached(t.Opts.DailyGoal) && t.today.reached(t.Opts.DailyGoal):
+		t.summary = "Daily goal of " + t.goalView() + " reached"
+	default:
+		return false
+	}
+
+	return true
+}
+
+// finish exits the timer after the hooks and notification for the last
+// session have completed.
+func (t *Timer) finish(hookCmd tea.Cmd) tea.Cmd {
+	t.flushBeforeExit()
+
+	_ = removeStatusFile()
+
+	slog.Info("timer finished", slog.String("reason", t.summary))
+
+	return tea.Sequence(
+		tea.Batch(hookCmd, t.alert(
+			string(config.Work+" is finished"),
+			t.summary,
+			t.Opts.BreakSound,
+		)),
+		tea.Quit,
+	)
+}
+
+// goalView describes the daily goal such as "8 sessions" or "4h".
+func (t *Timer) goalView() string {
+	goal := t.Opts.DailyGoal
+
+	switch {
+	case goal.Sessions == 1:
+		return "1 session"
+	case goal.Sessions > 1:
+		return fmt.Sprintf("%d sessions", goal.Sessions)
+	}
+
+	return formatWorkTime(goal.Duration)
+}
+
+// progressView describes the progress towards the daily goal such as
+// "3/8 today", or returns an empty string if no goal is set.
+func (t *Timer) progressView() string {
+	goal := t.Opts.DailyGoal
+
+	switch {
+	case goal.Sessions > 0:
+		return fmt.Sprintf("%d/%d today", t.today.sessions, goal.Sessions)
+	case goal.Duration > 0:
+		return fmt.Sprintf(
+			"%s/%s today",
+			formatWorkTime(t.today.worked),
+			formatWorkTime(goal.Duration),
+		)
+	}
+
+	return ""
+}
+
+// Summary describes why the timer exited and the work completed, or returns
+// an empty string if the timer was stopped by the user.
+func (t *Timer) Summary() string {
+	if t.summary == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s. Work sessions completed: %d in this run, %d today (%s)",
+		t.summary,
+		t.completed,
+		t.today.sessions,
+		formatWorkTime(t.today.worked),
+	)
+}
@@ -0,0 +1,224 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	btimer "github.com/charmbracelet/bubbles/timer"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type RecordWorkTest struct {
+	Name        string
+	DailyGoal   config.DailyGoal
+	Progress    string
+	MaxSessions int
+	// Earlier is the number of work sessions completed today before the
+	// timer started
+	Earlier int
+	// Completed is the number of work sessions completed by the timer
+	Completed int
+	Finished  bool
+}
+
+var recordWorkTestCases = []RecordWorkTest{
+	{
+		Name:      "No limit or goal",
+		Completed: 3,
+	},
+	{
+		Name:        "Maximum sessions reached",
+		MaxSessions: 2,
+		Earlier:     3,
+		Completed:   2,
+		Finished:    true,
+	},
+	{
+		Name:        "Maximum sessions not reached",
+		MaxSessions: 2,
+		Completed:   1,
+	},
+	{
+		Name:      "Daily goal reached",
+		DailyGoal: config.DailyGoal{Sessions: 4},
+		Earlier:   2,
+		Completed: 2,
+		Progress:  "4/4 today",
+		Finished:  true,
+	},
+	{
+		Name:      "Daily goal not reached",
+		DailyGoal: config.DailyGoal{Sessions: 4},
+		Earlier:   1,
+		Completed: 2,
+		Progress:  "3/4 today",
+	},
+	{
+		Name:      "Daily goal reached before the timer started",
+		DailyGoal: config.DailyGoal{Sessions: 2},
+		Earlier:   2,
+		Completed: 1,
+		Progress:  "3/2 today",
+	},
+	{
+		Name:      "Daily time goal reached",
+		DailyGoal: config.DailyGoal{Duration: time.Hour},
+		Earlier:   1,
+		Completed: 1,
+		Progress:  "1h/1h today",
+		Finished:  true,
+	},
+}
+
+func TestRecordWork(t *testing.T) {
+	for _, tc := range recordWorkTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			db := store.NewMemory()
+
+			timer := &Timer{
+				db: db,
+				Opts: &config.TimerConfig{
+					MaxSessions: tc.MaxSessions,
+					DailyGoal:   tc.DailyGoal,
+				},
+				clock: btimer.New(0),
+			}
+
+			// work sessions of 30 minutes that started in the last few
+			// minutes
+			start := time.Now().Add(-time.Duration(tc.Earlier+tc.Completed) * time.Minute)
+
+			addSession := func() {
+				end := start.Add(30 * time.Minute)
+
+				err := db.UpdateSessions(map[time.Time]*models.Session{
+					start: {
+						Name:      config.Work,
+						StartTime: start,
+						EndTime:   end,
+						Duration:  30 * time.Minute,
+						Completed: true,
+						Timeline: []models.SessionTimeline{
+							{StartTime: start, EndTime: end},
+						},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				start = start.Add(time.Minute)
+			}
+
+			for range tc.Earlier {
+				addSession()
+			}
+
+			err := timer.loadProgress()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var finished bool
+
+			// the sessions completed by the timer are counted without
+			// being saved, in case the database is unavailable
+			for range tc.Completed {
+				timer.Current = &Session{
+					Name:      config.Work,
+					StartTime: start,
+					Duration:  30 * time.Minute,
+					Timeline: []Timeline{
+						{StartTime: start, EndTime: start.Add(30 * time.Minute)},
+					},
+				}
+
+				finished = timer.recordWork()
+
+				start = start.Add(time.Minute)
+			}
+
+			if finished != tc.Finished {
+				t.Errorf("expected finished to be %t, but got: %t", tc.Finished, finished)
+			}
+
+			if got := timer.progressView(); got != tc.Progress {
+				t.Errorf("expected progress %q, but got: %q", tc.Progress, got)
+			}
+		})
+	}
+}
+
+func TestLoadEstimates(t *testing.T) {
+	db := store.NewMemory()
+
+	now := time.Now()
+	estimateStart := now.Add(-time.Hour)
+
+	// a session that was still running when the estimate was set, followed
+	// by two sessions after it, and one without the tag
+	sessions := map[time.Time]*models.Session{}
+
+	for _, v := range []struct {
+		start time.Time
+		tags  []string
+	}{
+		{estimateStart.Add(-10 * time.Minute), []string{"writing"}},
+		{estimateStart.Add(10 * time.Minute), []string{"writing"}},
+		{estimateStart.Add(40 * time.Minute), []string{"writing", "novel"}},
+		{estimateStart.Add(45 * time.Minute), []string{"reading"}},
+	} {
+		sessions[v.start] = &models.Session{
+			Name:      config.Work,
+			StartTime: v.start,
+			EndTime:   v.start.Add(25 * time.Minute),
+			Duration:  25 * time.Minute,
+			Tags:      v.tags,
+			Completed: true,
+		}
+	}
+
+	err := db.UpdateSessions(sessions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.BatchUpdate(func(b store.Batch) error {
+		for _, v := range []*models.TagEstimate{
+			{StartTime: estimateStart.Add(-time.Hour), Tag: "writing", Estimate: 2},
+			{StartTime: estimateStart, Tag: "writing", Estimate: 6},
+			{StartTime: estimateStart, Tag: "reading", Estimate: 3},
+			{StartTime: estimateStart.Add(time.Minute), Tag: "reading"},
+		} {
+			err := b.PutTagEstimate(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timer := &Timer{
+		db: db,
+		Opts: &config.TimerConfig{
+			Tags: []string{"reading", "writing"},
+		},
+	}
+
+	err = timer.loadEstimates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "tag writing: 2/6"
+	if got := timer.estimatesView(); got != expected {
+		t.Errorf("expected estimates %q, but got: %q", expected, got)
+	}
+}
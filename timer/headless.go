@@ -0,0 +1,54 @@
+package timer
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stopMsg asks the timer to save the current session and exit.
+type stopMsg struct{}
+
+// NewProgram returns the program that runs the timer. In headless mode,
+// nothing is rendered and no input is read so that the timer can run without
+// a terminal (for example, as a service). It is controlled through the control
+// socket instead, and signals are left to StopOnSignal.
+func (t *Timer) NewProgram(headless bool) *tea.Program {
+	t.headless = headless
+
+	if !headless {
+		return tea.NewProgram(t)
+	}
+
+	return tea.NewProgram(
+		t,
+		tea.WithoutRenderer(),
+		tea.WithInput(nil),
+		tea.WithoutSignalHandler(),
+	)
+}
+
+// StopOnSignal stops the timer in the same way as the quit key when the
+// process is interrupted or terminated, so that the current session is saved
+// before p exits. The returned function stops listening for signals.
+func StopOnSignal(p *tea.Program) func() {
+	sig := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sig:
+			p.Send(stopMsg{})
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
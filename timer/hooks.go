@@ -0,0 +1,163 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kballard/go-shellquote"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// sessionCmdEvent identifies the legacy session_cmd option which runs after
+// every session in the logs.
+const sessionCmdEvent config.HookEvent = "session_cmd"
+
+// startEvent returns the hook event for the start of the current session.
+func (t *Timer) startEvent() config.HookEvent {
+	if t.Current.Name == config.Work {
+		return config.HookWorkStart
+	}
+
+	return config.HookBreakStart
+}
+
+// endEvent returns the hook event for the end of the current session.
+func (t *Timer) endEvent() config.HookEvent {
+	if t.Current.Name == config.Work {
+		return config.HookWorkEnd
+	}
+
+	return config.HookBreakEnd
+}
+
+// hookEnv describes the current session through environmental variables
+// which are passed on to hooks.
+func (t *Timer) hookEnv(event config.HookEvent) []string {
+	sess := t.Current
+
+	remaining := t.clock.Timeout
+	if t.waitForNextSession || remaining > sess.Duration {
+		remaining = sess.Duration
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	elapsed := sess.Duration - remaining
+
+	return []string{
+		"FOCUS_EVENT=" + string(event),
+		"FOCUS_SESSION_NAME=" + string(sess.Name),
+		"FOCUS_SESSION_TYPE=" + sess.Type,
+		"FOCUS_TAGS=" + strings.Join(sess.Tags, ","),
+		"FOCUS_WORK_CYCLE=" + strconv.Itoa(t.WorkCycle),
+		"FOCUS_LONG_BREAK_INTERVAL=" + strconv.Itoa(t.Opts.Cycle.WorkSessions()),
+		"FOCUS_DURATION=" + strconv.Itoa(int(sess.Duration.Seconds())),
+		"FOCUS_ELAPSED=" + strconv.Itoa(int(elapsed.Round(time.Second).Seconds())),
+		"FOCUS_REMAINING=" + strconv.Itoa(int(remaining.Round(time.Second).Seconds())),
+		"FOCUS_START_TIME=" + sess.StartTime.Format(time.RFC3339),
+		"FOCUS_END_TIME=" + sess.EndTime.Format(time.RFC3339),
+		"FOCUS_COMPLETED=" + strconv.FormatBool(sess.Completed || t.clock.Timedout()),
+		"FOCUS_SKIPPED=" + strconv.FormatBool(sess.Skipped),
+	}
+}
+
+// runHookCmd executes a hook command with the provided environmental variables
+// and kills it if it doesn't complete within the configured timeout.
+func runHookCmd(
+	hookCmd string,
+	env []string,
+	timeout time.Duration,
+) error {
+	cmdSlice, err := shellquote.Split(hookCmd)
+	if err != nil {
+		return fmt.Errorf("unable to parse hook command: %w", err)
+	}
+
+	if len(cmdSlice) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	defer cancel()
+
+	name := cmdSlice[0]
+	args := cmdSlice[1:]
+
+	//nolint:gosec // running user-defined commands is intended
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return fmt.Errorf("hook timed out after %s: %w", timeout, ctx.Err())
+	}
+
+	if output := strings.TrimSpace(string(out)); err != nil && output != "" {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+
+	return err
+}
+
+// hook returns a command that executes the hooks configured for the specified
+// event (if any) outside the event loop. Failures are logged but otherwise
+// ignored.
+func (t *Timer) hook(event config.HookEvent) tea.Cmd {
+	slog.Info(
+		"session event",
+		slog.String("event", string(event)),
+		slog.String("session", string(t.Current.Name)),
+		slog.Int("work_cycle", t.WorkCycle),
+	)
+
+	var cmds [][2]string
+
+	if hookCmd := t.Opts.Hooks[event]; hookCmd != "" {
+		cmds = append(cmds, [2]string{string(event), hookCmd})
+	}
+
+	// session_cmd is executed at the end of every session
+	if t.Opts.SessionCmd != "" &&
+		(event == config.HookWorkEnd || event == config.HookBreakEnd) {
+		cmds = append(cmds, [2]string{string(sessionCmdEvent), t.Opts.SessionCmd})
+	}
+
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	env := t.hookEnv(event)
+	timeout := t.Opts.HookTimeout
+
+	return func() tea.Msg {
+		for _, v := range cmds {
+			name, hookCmd := v[0], v[1]
+
+			err := runHookCmd(hookCmd, env, timeout)
+			if err != nil {
+				slog.Error(
+					"hook failed",
+					slog.String("event", name),
+					slog.String("cmd", hookCmd),
+					slog.Any("error", err),
+				)
+			}
+		}
+
+		return nil
+	}
+}
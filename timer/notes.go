@@ -0,0 +1,167 @@
+package timer
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+var (
+	intentView     settingsView = "intent"
+	reflectionView settingsView = "reflection"
+)
+
+// editingNote reports whether the intent or reflection form is open.
+func (t *Timer) editingNote() bool {
+	return t.settings == intentView || t.settings == reflectionView
+}
+
+// openNote opens the form for the intent of the current work session or the
+// reflection on the last work session.
+func (t *Timer) openNote(view settingsView) tea.Cmd {
+	title := "What do you want to get done?"
+	t.note = t.Current.Intent
+
+	if view == reflectionView {
+		title = "What did you get done?"
+		t.note = ""
+
+		sess, err := t.db.GetSession(t.lastWork)
+		if err == nil {
+			t.note = sess.Notes
+		}
+	}
+
+	t.settings = view
+	t.noteForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(title).
+				Value(&t.note),
+		),
+	).WithShowHelp(false)
+
+	return t.noteForm.Init()
+}
+
+// promptIntent opens the intent form when a new work session starts if
+// session notes are enabled. If the reflection on the last work session is
+// still being written, the intent form is opened once it is closed instead.
+func (t *Timer) promptIntent() tea.Cmd {
+	if !t.Opts.SessionNotes || t.headless ||
+		t.Current.Name != config.Work || t.Current.Intent != "" {
+		return nil
+	}
+
+	if t.editingNote() {
+		t.intentQueued = true
+		return nil
+	}
+
+	return t.openNote(intentView)
+}
+
+// closeNote closes the open note form, and opens the intent form if it was
+// held back while the form was open.
+func (t *Timer) closeNote() tea.Cmd {
+	t.settings = ""
+
+	if !t.intentQueued {
+		return nil
+	}
+
+	t.intentQueued = false
+
+	return t.promptIntent()
+}
+
+// promptReflection opens the reflection form when a work session ends if
+// session notes are enabled.
+func (t *Timer) promptReflection() tea.Cmd {
+	if !t.Opts.SessionNotes || t.headless {
+		return nil
+	}
+
+	return t.openNote(reflectionView)
+}
+
+// toggleNote opens the intent form during a work session, and the reflection
+// form for the last work session otherwise.
+func (t *Timer) toggleNote() tea.Cmd {
+	if t.Current.Name == config.Work && !t.waitForNextSession {
+		return t.openNote(intentView)
+	}
+
+	if t.lastWork.IsZero() {
+		return nil
+	}
+
+	return t.openNote(reflectionView)
+}
+
+// updateNote passes msg on to the open note form, and saves the note once the
+// form is submitted.
+func (t *Timer) updateNote(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, defaultKeymap.discard) {
+		return t.closeNote()
+	}
+
+	form, cmd := t.noteForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		t.noteForm = f
+	}
+
+	switch t.noteForm.State {
+	case huh.StateCompleted:
+		note := strings.TrimSpace(t.note)
+
+		if t.settings == intentView {
+			cmd = tea.Batch(cmd, t.setIntent(note))
+		} else {
+			t.setReflection(note)
+		}
+
+		return tea.Batch(cmd, t.closeNote())
+	case huh.StateAborted:
+		return tea.Batch(cmd, t.closeNote())
+	case huh.StateNormal:
+	}
+
+	return cmd
+}
+
+// setIntent sets the intent of the current work session.
+func (t *Timer) setIntent(intent string) tea.Cmd {
+	t.Current.Intent = intent
+
+	if t.waitForNextSession {
+		return nil
+	}
+
+	_ = t.writeStatusFile()
+
+	return t.persist()
+}
+
+// setReflection saves the notes of the last work session.
+func (t *Timer) setReflection(notes string) {
+	sess, err := t.db.GetSession(t.lastWork)
+	if err == nil {
+		sess.Notes = notes
+
+		err = t.db.UpdateSessions(map[time.Time]*models.Session{
+			sess.StartTime: sess,
+		})
+	}
+
+	if err != nil {
+		slog.Error("unable to save session notes", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,145 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	btimer "github.com/charmbracelet/bubbles/timer"
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type NoteTest struct {
+	Name   string
+	View   settingsView
+	Note   string
+	Intent string
+	Notes  string
+}
+
+var noteTestCases = []NoteTest{
+	{
+		Name:   "Intent of the current work session",
+		View:   intentView,
+		Note:   "  Outline chapter 3 ",
+		Intent: "Outline chapter 3",
+	},
+	{
+		Name:  "Reflection on the last work session",
+		View:  reflectionView,
+		Note:  "Done, but the ending needs work",
+		Notes: "Done, but the ending needs work",
+	},
+}
+
+func TestNotes(t *testing.T) {
+	for _, tc := range noteTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			db := store.NewMemory()
+
+			timer := &Timer{
+				db: db,
+				Opts: &config.TimerConfig{
+					Duration: config.Duration{
+						config.Work: 25 * time.Minute,
+					},
+				},
+				clock: btimer.New(10 * time.Minute),
+			}
+
+			timer.Current = timer.newSession(timer.Opts.Step(config.Work))
+
+			err := persistNow(timer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			timer.lastWork = timer.Current.StartTime
+
+			_ = timer.openNote(tc.View)
+
+			timer.note = tc.Note
+			timer.noteForm.State = huh.StateCompleted
+
+			_ = timer.updateNote(nil)
+
+			if timer.editingNote() {
+				t.Error("expected the note form to be closed")
+			}
+
+			err = timer.flush()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sess, err := db.GetSession(timer.Current.StartTime)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sess.Intent != tc.Intent {
+				t.Errorf("expected intent %q, but got: %q", tc.Intent, sess.Intent)
+			}
+
+			if sess.Notes != tc.Notes {
+				t.Errorf("expected notes %q, but got: %q", tc.Notes, sess.Notes)
+			}
+		})
+	}
+}
+
+func TestIntentAfterReflection(t *testing.T) {
+	db := store.NewMemory()
+
+	timer := &Timer{
+		db: db,
+		Opts: &config.TimerConfig{
+			Duration: config.Duration{
+				config.Work: 25 * time.Minute,
+			},
+			SessionNotes: true,
+		},
+		clock: btimer.New(25 * time.Minute),
+	}
+
+	timer.Current = timer.newSession(timer.Opts.Step(config.Work))
+
+	err := persistNow(timer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timer.lastWork = timer.Current.StartTime
+
+	_ = timer.promptReflection()
+
+	timer.note = "Done, but the ending needs work"
+
+	// the next work session starts while the reflection is being written
+	timer.Current = timer.newSession(timer.Opts.Step(config.Work))
+
+	_ = timer.promptIntent()
+
+	if timer.settings != reflectionView || timer.note == "" {
+		t.Fatal("expected the reflection form to stay open")
+	}
+
+	timer.noteForm.State = huh.StateCompleted
+
+	_ = timer.updateNote(nil)
+
+	if timer.settings != intentView {
+		t.Errorf("expected the intent form to open after the reflection, but got: %q", timer.settings)
+	}
+
+	sess, err := db.GetSession(timer.lastWork)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sess.Notes != "Done, but the ending needs work" {
+		t.Errorf("expected the reflection to be saved, but got: %q", sess.Notes)
+	}
+}
@@ -0,0 +1,91 @@
+package timer
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gen2brain/beeep"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// Notifier delivers desktop notifications and alert sounds when a session
+// ends.
+type Notifier interface {
+	// Notify displays a notification with the specified title, message, and
+	// icon (which may be empty)
+	Notify(title, msg, icon string) error
+	// PlaySound plays the specified alert sound to completion
+	PlaySound(sound string) error
+}
+
+// desktopNotifier is the default Notifier which uses the native notification
+// system and the speaker.
+type desktopNotifier struct{}
+
+func (desktopNotifier) Notify(title, msg, icon string) error {
+	return beeep.Notify(title, msg, icon)
+}
+
+func (desktopNotifier) PlaySound(sound string) error {
+	return playSound(sound)
+}
+
+// notify returns a command that sends a desktop notification and plays a
+// notification sound when a session ends if enabled. The command runs outside
+// the event loop so the countdown is not blocked while the sound is playing.
+func (t *Timer) notify(sessName config.SessType, next config.Step) tea.Cmd {
+	title := string(sessName + " is finished")
+
+	sound := t.Opts.BreakSound
+
+	if sessName != config.Work {
+		sound = t.Opts.WorkSound
+	}
+
+	return t.alert(title, next.Message, sound)
+}
+
+// alert returns a command that sends a desktop notification with the
+// specified title and message, and plays the specified sound.
+func (t *Timer) alert(title, msg, sound string) tea.Cmd {
+	if !t.Opts.Notify || t.Notifier == nil {
+		return nil
+	}
+
+	configDir := filepath.Base(filepath.Dir(t.Opts.PathToConfig))
+
+	// pathToIcon will be an empty string if file is not found
+	pathToIcon, _ := xdg.SearchDataFile(
+		filepath.Join(configDir, "static", "icon.png"),
+	)
+
+	notifier := t.Notifier
+
+	return func() tea.Msg {
+		err := notifier.Notify(title, msg, pathToIcon)
+		if err != nil {
+			slog.Error(
+				"unable to display notification",
+				slog.Any("error", err),
+			)
+		}
+
+		if sound == config.SoundOff || sound == "" {
+			return nil
+		}
+
+		err = notifier.PlaySound(sound)
+		if err != nil {
+			slog.Error(
+				"unable to play sound",
+				slog.String("sound", sound),
+				slog.Any("error", err),
+			)
+		}
+
+		return nil
+	}
+}
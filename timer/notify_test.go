@@ -0,0 +1,156 @@
+package timer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+type notification struct {
+	Title string
+	Msg   string
+}
+
+type fakeNotifier struct {
+	notifications []notification
+	sounds        []string
+}
+
+func (f *fakeNotifier) Notify(title, msg, _ string) error {
+	f.notifications = append(f.notifications, notification{
+		Title: title,
+		Msg:   msg,
+	})
+
+	return nil
+}
+
+func (f *fakeNotifier) PlaySound(sound string) error {
+	f.sounds = append(f.sounds, sound)
+
+	return nil
+}
+
+type NotifyTest struct {
+	Name          string
+	Current       config.SessType
+	Next          config.SessType
+	WorkSound     string
+	BreakSound    string
+	Notifications []notification
+	Sounds        []string
+	Disabled      bool
+}
+
+var notifyTestCases = []NotifyTest{
+	{
+		Name:       "Work session to short break",
+		Current:    config.Work,
+		Next:       config.ShortBreak,
+		WorkSound:  "loud_bell",
+		BreakSound: "bell",
+		Notifications: []notification{
+			{Title: "Work session is finished", Msg: "Take a breather"},
+		},
+		Sounds: []string{"bell"},
+	},
+	{
+		Name:       "Work session to long break",
+		Current:    config.Work,
+		Next:       config.LongBreak,
+		WorkSound:  "loud_bell",
+		BreakSound: "bell",
+		Notifications: []notification{
+			{Title: "Work session is finished", Msg: "Take a long break"},
+		},
+		Sounds: []string{"bell"},
+	},
+	{
+		Name:       "Short break to work session",
+		Current:    config.ShortBreak,
+		Next:       config.Work,
+		WorkSound:  "loud_bell",
+		BreakSound: "bell",
+		Notifications: []notification{
+			{Title: "Short break is finished", Msg: "Focus on your task"},
+		},
+		Sounds: []string{"loud_bell"},
+	},
+	{
+		Name:       "Sound is turned off",
+		Current:    config.Work,
+		Next:       config.ShortBreak,
+		WorkSound:  "loud_bell",
+		BreakSound: config.SoundOff,
+		Notifications: []notification{
+			{Title: "Work session is finished", Msg: "Take a breather"},
+		},
+	},
+	{
+		Name:     "Notifications are disabled",
+		Current:  config.Work,
+		Next:     config.ShortBreak,
+		Disabled: true,
+	},
+}
+
+func TestNotify(t *testing.T) {
+	for _, tc := range notifyTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			n := &fakeNotifier{}
+
+			timer := &Timer{
+				Notifier: n,
+				Opts: &config.TimerConfig{
+					Duration: config.Duration{
+						config.Work:       25 * time.Minute,
+						config.ShortBreak: 5 * time.Minute,
+						config.LongBreak:  15 * time.Minute,
+					},
+					Message: config.Message{
+						config.Work:       "Focus on your task",
+						config.ShortBreak: "Take a breather",
+						config.LongBreak:  "Take a long break",
+					},
+					Notify:     !tc.Disabled,
+					WorkSound:  tc.WorkSound,
+					BreakSound: tc.BreakSound,
+				},
+			}
+
+			cmd := timer.notify(tc.Current, timer.Opts.Step(tc.Next))
+
+			if tc.Disabled {
+				if cmd != nil {
+					t.Fatal("expected no command when notifications are disabled")
+				}
+
+				return
+			}
+
+			if cmd == nil {
+				t.Fatal("expected a notification command")
+			}
+
+			_ = cmd()
+
+			if !slices.Equal(n.notifications, tc.Notifications) {
+				t.Errorf(
+					"expected notifications to be: %v, but got: %v",
+					tc.Notifications,
+					n.notifications,
+				)
+			}
+
+			if !slices.Equal(n.sounds, tc.Sounds) {
+				t.Errorf(
+					"expected sounds to be: %v, but got: %v",
+					tc.Sounds,
+					n.sounds,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	btimer "github.com/charmbracelet/bubbles/timer"
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+// pausedTimerLabel describes an interrupted session for the selection prompt.
+func pausedTimerLabel(
+	index int,
+	pt *models.Timer,
+	sess *models.Session,
+) string {
+	var elapsed time.Duration
+	for _, v := range sess.Timeline {
+		elapsed += v.EndTime.Sub(v.StartTime)
+	}
+
+	m, s := timeutil.SecsToMinsAndSecs((sess.Duration - elapsed).Seconds())
+
+	label := fmt.Sprintf(
+		"%d. %s · paused %s · %02d:%02d left",
+		index,
+		sess.StartTime.Format("Jan 02, 2006 03:04 PM"),
+		pt.PausedTime.Format("Jan 02 03:04 PM"),
+		m,
+		s,
+	)
+
+	if len(sess.Tags) > 0 {
+		label += " · " + strings.Join(sess.Tags, ", ")
+	}
+
+	return label
+}
+
+// selectPausedTimer prompts the user to pick one of the interrupted sessions
+// and returns its index (starting from 1).
+func selectPausedTimer(
+	timers []*models.Timer,
+	sessions map[string]*models.Session,
+) (int, error) {
+	opts := make([]huh.Option[int], len(timers))
+
+	for i, v := range timers {
+		sess := sessions[string(timeutil.ToKey(v.SessionKey))]
+		opts[i] = huh.NewOption(pausedTimerLabel(i+1, v, sess), i+1)
+	}
+
+	var index int
+
+	err := huh.NewSelect[int]().
+		Title("Select the session to resume").
+		Options(opts...).
+		Value(&index).
+		Run()
+
+	return index, err
+}
+
+// Resume prepares the timer to continue an interrupted work session. The
+// session is selected by its index in the list of paused timers (most recent
+// first), or interactively if index is zero. If reset is true, the work cycle
+// starts again from the beginning of the set.
+func (t *Timer) Resume(index int, reset bool) error {
+	if t.Opts.Strict {
+		return errStrictMode
+	}
+
+	timers, sessions, err := getTimerSessions(t.db)
+	if err != nil {
+		return err
+	}
+
+	if len(timers) == 0 {
+		return errNoPausedTimers
+	}
+
+	if index == 0 {
+		index, err = selectPausedTimer(timers, sessions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if index < 1 || index > len(timers) {
+		return errInvalidSessionIndex
+	}
+
+	pt := timers[index-1]
+
+	sess := newSessionFromDBModel(
+		sessions[string(timeutil.ToKey(pt.SessionKey))],
+	)
+
+	// continue the countdown from where the session was interrupted
+	sess.SetEndTime()
+
+	t.Current = sess
+	t.WorkCycle = pt.WorkCycle
+
+	if sess.TaskID != 0 {
+		// the sessions that follow are only linked to the task if it still exists
+		task, err := t.db.GetTask(sess.TaskID)
+		if err == nil {
+			t.task = task
+		}
+	}
+
+	if reset || t.WorkCycle < 1 || t.WorkCycle > t.Opts.Cycle.WorkSessions() {
+		t.WorkCycle = 1
+	}
+
+	t.step = t.Opts.Cycle.WorkStep(t.WorkCycle)
+
+	t.clock = btimer.New(time.Until(sess.EndTime).Round(time.Second))
+
+	return nil
+}
@@ -23,10 +23,15 @@ type (
 		StartTime time.Time       `json:"start_time"`
 		EndTime   time.Time       `json:"end_time"`
 		Name      config.SessType `json:"name"`
+		Type      string          `json:"type"`
 		Tags      []string        `json:"tags"`
 		Timeline  []Timeline      `json:"timeline"`
 		Duration  time.Duration   `json:"duration"`
 		Completed bool            `json:"completed"`
+		Skipped   bool            `json:"skipped"`
+		Intent    string          `json:"intent"`
+		Notes     string          `json:"notes"`
+		TaskID    int             `json:"task_id"`
 	}
 
 	// Remainder is the time remaining in an active session.
@@ -46,6 +51,16 @@ func (s *Session) IsResuming() bool {
 	return true
 }
 
+// Label returns the name of a user-defined session type, or the name of the
+// built-in session otherwise.
+func (s *Session) Label() string {
+	if s.Type != "" && !config.BuiltinSessionType(s.Type) {
+		return s.Type
+	}
+
+	return string(s.Name)
+}
+
 func (s *Session) Adjust(since time.Time) {
 	s.StartTime = since
 	s.EndTime = s.StartTime.Add(s.Duration)
@@ -188,9 +203,14 @@ func (s *Session) ToDBModel() *models.Session {
 	sess.StartTime = s.StartTime
 	sess.EndTime = s.EndTime
 	sess.Name = s.Name
+	sess.Type = s.Type
 	sess.Tags = s.Tags
 	sess.Duration = s.Duration
 	sess.Completed = s.Completed
+	sess.Skipped = s.Skipped
+	sess.Intent = s.Intent
+	sess.Notes = s.Notes
+	sess.TaskID = s.TaskID
 
 	for _, v := range s.Timeline {
 		timeline := models.SessionTimeline{
@@ -203,3 +223,29 @@ func (s *Session) ToDBModel() *models.Session {
 
 	return sess
 }
+
+// newSessionFromDBModel converts a database model to an active session.
+func newSessionFromDBModel(s *models.Session) *Session {
+	sess := &Session{
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		Name:      s.Name,
+		Type:      s.Type,
+		Tags:      s.Tags,
+		Duration:  s.Duration,
+		Completed: s.Completed,
+		Skipped:   s.Skipped,
+		Intent:    s.Intent,
+		Notes:     s.Notes,
+		TaskID:    s.TaskID,
+	}
+
+	for _, v := range s.Timeline {
+		sess.Timeline = append(sess.Timeline, Timeline{
+			StartTime: v.StartTime,
+			EndTime:   v.EndTime,
+		})
+	}
+
+	return sess
+}
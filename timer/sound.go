@@ -1,10 +1,12 @@
 package timer
 
 import (
+	"errors"
 	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gopxl/beep/v2"
@@ -20,6 +22,12 @@ import (
 
 var soundOpts []string
 
+var (
+	speakerOnce       sync.Once
+	speakerSampleRate beep.SampleRate
+	speakerErr        error
+)
+
 func init() {
 	dir, err := fs.ReadDir(
 		static.Files,
@@ -36,7 +44,7 @@ func init() {
 }
 
 // prepSoundStream returns an audio stream for the specified sound.
-func prepSoundStream(sound string) (beep.StreamSeekCloser, error) {
+func prepSoundStream(sound string) (beep.StreamSeekCloser, beep.Format, error) {
 	var (
 		f      fs.File
 		err    error
@@ -50,15 +58,19 @@ func prepSoundStream(sound string) (beep.StreamSeekCloser, error) {
 		sound += ".ogg"
 
 		f, err = static.Files.Open(static.AmbientSound(sound))
+		if errors.Is(err, fs.ErrNotExist) {
+			f, err = static.Files.Open(static.AlertSound(sound))
+		}
+
 		if err != nil {
 			// TODO: Update error
-			return nil, err
+			return nil, format, err
 		}
 	} else {
 		f, err = os.Open(sound)
 		// TODO: Update error
 		if err != nil {
-			return nil, err
+			return nil, format, err
 		}
 	}
 
@@ -78,36 +90,75 @@ func prepSoundStream(sound string) (beep.StreamSeekCloser, error) {
 	case ".wav":
 		stream, format, err = wav.Decode(f)
 	default:
-		return nil, errInvalidSoundFormat
+		return nil, format, errInvalidSoundFormat
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, format, err
 	}
 
-	bufferSize := 10
+	err = stream.Seek(0)
+	if err != nil {
+		return nil, format, err
+	}
 
-	err = speaker.Init(
-		format.SampleRate,
-		format.SampleRate.N(time.Duration(int(time.Second)/bufferSize)),
-	)
+	return stream, format, nil
+}
+
+// initSpeaker initialises the speaker with the sample rate of the first sound
+// to be played. The speaker cannot be initialised more than once, so
+// subsequent sounds are resampled to this rate.
+func initSpeaker(sampleRate beep.SampleRate) error {
+	speakerOnce.Do(func() {
+		bufferSize := 10
+
+		speakerSampleRate = sampleRate
+		speakerErr = speaker.Init(
+			sampleRate,
+			sampleRate.N(time.Duration(int(time.Second)/bufferSize)),
+		)
+	})
+
+	return speakerErr
+}
+
+// playSound plays the specified sound once and blocks until it is done.
+func playSound(sound string) error {
+	stream, format, err := prepSoundStream(sound)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = stream.Seek(0)
+	defer stream.Close()
+
+	err = initSpeaker(format.SampleRate)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	var s beep.Streamer = stream
+
+	if format.SampleRate != speakerSampleRate {
+		quality := 4
+		s = beep.Resample(quality, format.SampleRate, speakerSampleRate, stream)
 	}
 
-	return stream, nil
+	done := make(chan bool)
+
+	speaker.Play(beep.Seq(s, beep.Callback(func() {
+		done <- true
+	})))
+
+	<-done
+
+	return nil
 }
 
 func (t *Timer) setAmbientSound() error {
 	var infiniteStream beep.Streamer
 
 	if t.Opts.AmbientSound != "" {
-		stream, err := prepSoundStream(t.Opts.AmbientSound)
+		stream, _, err := prepSoundStream(t.Opts.AmbientSound)
 		if err != nil {
 			return err
 		}
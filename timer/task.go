@@ -0,0 +1,62 @@
+package timer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// TaskLabel describes a task for the selection prompt and the timer.
+func TaskLabel(task *models.Task) string {
+	return fmt.Sprintf("#%d %s", task.ID, task.Name)
+}
+
+// SetTask records the sessions that follow against the specified task, and
+// applies the tags of the task to them.
+func (t *Timer) SetTask(task *models.Task) {
+	t.task = task
+
+	for _, tag := range task.Tags {
+		if !slices.Contains(t.Opts.Tags, tag) {
+			t.Opts.Tags = append(t.Opts.Tags, tag)
+		}
+	}
+}
+
+// taskID returns the ID of the task that the timer is working on, or zero if
+// there isn't one.
+func (t *Timer) taskID() int {
+	if t.task == nil {
+		return 0
+	}
+
+	return t.task.ID
+}
+
+// SelectTask prompts the user to pick the task to work on from the provided
+// tasks. It returns nil if the user chooses not to work on a task.
+func SelectTask(tasks []*models.Task) (*models.Task, error) {
+	opts := make([]huh.Option[int], 0, len(tasks)+1)
+
+	opts = append(opts, huh.NewOption("No task", 0))
+
+	for i, v := range tasks {
+		opts = append(opts, huh.NewOption(TaskLabel(v), i+1))
+	}
+
+	var index int
+
+	err := huh.NewSelect[int]().
+		Title("Select the task to work on").
+		Options(opts...).
+		Value(&index).
+		Run()
+	if err != nil || index == 0 {
+		return nil, err
+	}
+
+	return tasks[index-1], nil
+}
@@ -3,18 +3,16 @@
 package timer
 
 import (
-	"bufio"
-	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 
-	"github.com/adrg/xdg"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
@@ -23,16 +21,9 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/faiface/beep"
-	"github.com/faiface/beep/speaker"
-	"github.com/gen2brain/beeep"
-	"github.com/kballard/go-shellquote"
-	"github.com/pterm/pterm"
-	bolt "go.etcd.io/bbolt"
-	bolterr "go.etcd.io/bbolt/errors"
 
 	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
-	"github.com/ayoisaiah/focus/internal/pathutil"
 	"github.com/ayoisaiah/focus/report"
 	"github.com/ayoisaiah/focus/store"
 )
@@ -42,36 +33,79 @@ type (
 
 	// Timer represents a running timer.
 	Timer struct {
-		help               help.Model
-		StartTime          time.Time           `json:"start_time"`
-		SessionKey         time.Time           `json:"session_key"`
-		PausedTime         time.Time           `json:"paused_time"`
-		SoundStream        beep.Streamer       `json:"-"`
+		help        help.Model
+		StartTime   time.Time     `json:"start_time"`
+		SessionKey  time.Time     `json:"session_key"`
+		PausedTime  time.Time     `json:"paused_time"`
+		SoundStream beep.Streamer `json:"-"`
+		// Notifier delivers the notifications and sounds at the end of a session
+		Notifier           Notifier            `json:"-"`
 		db                 store.DB            `json:"-"`
 		Opts               *config.TimerConfig `json:"opts"`
 		Current            *Session
 		soundForm          *huh.Form
+		noteForm           *huh.Form
 		settings           settingsView
 		progress           progress.Model
 		clock              btimer.Model
 		WorkCycle          int `json:"work_cycle"`
 		waitForNextSession bool
+		// confirmAbandon is set when a pause is attempted in strict mode
+		confirmAbandon bool
+		// abandoned is set when the previous session was abandoned in strict mode
+		abandoned bool
+		// today is the work recorded today for tracking the daily goal
+		today dailyProgress
+		// completed is the number of work sessions completed in this run
+		completed int
+		// summary describes why the timer exited on its own
+		summary string
+		// note is the value of the open intent or reflection form
+		note string
+		// intentQueued is set when the intent form is held back until the
+		// reflection form is closed
+		intentQueued bool
+		// lastWork is the start time of the last work session that ended
+		lastWork time.Time
+		// headless is set when the timer runs without a terminal
+		headless bool
+		// task is what the sessions are spent on, if any
+		task *models.Task
+		// estimates is the work completed towards the estimates of the tags
+		estimates []tagProgress
+		// step is the index of the current session in the cycle
+		step int
+		// unsaved are the changes that could not be saved to the database
+		// yet
+		unsaved []func(b store.Batch) error
+		// saveErr is the reason that the unsaved changes could not be saved
+		saveErr error
+		// saving is set while a save is in progress
+		saving bool
+		// saveMu is held while a save writes to the database
+		saveMu sync.Mutex
+		// saveGen is incremented when the unsaved changes are flushed, so
+		// that saves which were started earlier are not applied after them
+		saveGen int
 	}
 
 	keymap struct {
 		togglePlay key.Binding
 		sound      key.Binding
+		note       key.Binding
+		save       key.Binding
+		discard    key.Binding
 		enter      key.Binding
 		quit       key.Binding
+		strictQuit key.Binding
 		esc        key.Binding
+		confirm    key.Binding
+		cancel     key.Binding
 	}
 
 	style struct {
-		work       lipgloss.Style
-		shortBreak lipgloss.Style
-		longBreak  lipgloss.Style
-		base       lipgloss.Style
-		help       lipgloss.Style
+		base lipgloss.Style
+		help lipgloss.Style
 	}
 )
 
@@ -80,6 +114,15 @@ const (
 	maxWidth = 80
 )
 
+const (
+	// saveRetryInterval is how often in seconds the changes that could not be
+	// saved are retried while a session is running
+	saveRetryInterval = 5
+	// exitSaveAttempts is the number of times that the changes that could not
+	// be saved are retried when the timer exits
+	exitSaveAttempts = 3
+)
+
 var (
 	defaultStyle  style
 	defaultKeymap = keymap{
@@ -91,6 +134,18 @@ var (
 			key.WithKeys("s"),
 			key.WithHelp("s", "sound"),
 		),
+		note: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "note"),
+		),
+		save: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save"),
+		),
+		discard: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
 		enter: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp(
@@ -102,10 +157,22 @@ var (
 			key.WithKeys("ctrl+c", "q"),
 			key.WithHelp("q", "quit"),
 		),
+		strictQuit: key.NewBinding(
+			key.WithKeys("ctrl+c", "q"),
+			key.WithHelp("q", "quit (abandons session)"),
+		),
 		esc: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "skip"),
 		),
+		confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "abandon session"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("n", "esc"),
+			key.WithHelp("n", "cancel"),
+		),
 	}
 )
 
@@ -114,18 +181,6 @@ var soundView settingsView = "sound"
 // New creates a new timer.
 func New(dbClient store.DB, cfg *config.TimerConfig) (*Timer, error) {
 	defaultStyle = style{
-		work: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.WorkColor)).
-			MarginRight(1).
-			SetString(cfg.Message[config.Work]),
-		shortBreak: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.ShortBreakColor)).
-			MarginRight(1).
-			SetString(cfg.Message[config.ShortBreak]),
-		longBreak: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.LongBreakColor)).
-			MarginRight(1).
-			SetString(cfg.Message[config.LongBreak]),
 		base: lipgloss.NewStyle().Padding(1, 1),
 		help: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
@@ -135,6 +190,7 @@ func New(dbClient store.DB, cfg *config.TimerConfig) (*Timer, error) {
 	t := &Timer{
 		db:       dbClient,
 		Opts:     cfg,
+		Notifier: desktopNotifier{},
 		help:     help.New(),
 		progress: progress.New(progress.WithDefaultGradient()),
 		soundForm: huh.NewForm(
@@ -152,24 +208,50 @@ func New(dbClient store.DB, cfg *config.TimerConfig) (*Timer, error) {
 	return t, err
 }
 
+// Init starts the countdown for a new or resumed session, or exits for
 // sessions added with the --since flag.
 func (t *Timer) Init() tea.Cmd {
 	t.StartTime = time.Now()
 
-	err := t.new()
+	if t.Opts.DailyGoal.IsSet() {
+		err := t.loadProgress()
+		if err != nil {
+			slog.Error("unable to compute daily progress", slog.Any("error", err))
+		}
+	}
 
-	// If --since is used to add a completed session
-	if t.Current.Completed {
-		report.SessionAdded()
+	err := t.loadEstimates()
+	if err != nil {
+		slog.Error("unable to compute tag estimates", slog.Any("error", err))
+	}
 
-		return tea.Quit
+	// A resumed session is already set up through Resume
+	if t.Current != nil {
+		return tea.Batch(
+			t.clock.Init(),
+			t.soundForm.Init(),
+			t.hook(config.HookResume),
+		)
 	}
 
+	err = t.new()
 	if err != nil {
 		return report.Fatal(err)
 	}
 
-	return tea.Batch(t.clock.Init(), t.soundForm.Init())
+	// If --since is used to add a completed session
+	if t.Current.Completed {
+		report.SessionAdded()
+
+		return tea.Quit
+	}
+
+	return tea.Batch(
+		t.clock.Init(),
+		t.soundForm.Init(),
+		t.hook(t.startEvent()),
+		t.promptIntent(),
+	)
 }
 
 // new creates a new timer.
@@ -180,24 +262,25 @@ func (t *Timer) new() error {
 	}
 
 	t.Current = sess
+	t.step = 0
 	t.WorkCycle = 1
 	t.clock = btimer.New(t.Current.Duration)
 
 	return nil
 }
 
-// newSession creates a new session.
-func (t *Timer) newSession(
-	name config.SessType,
-) *Session {
-	duration := t.Opts.Duration[name]
+// newSession creates a new session for the specified step in the cycle.
+func (t *Timer) newSession(step config.Step) *Session {
+	duration := step.Duration
 	startTime := time.Now()
 	endTime := startTime.Add(duration)
 
 	return &Session{
-		Name:      name,
+		Name:      step.Kind,
+		Type:      step.Name,
 		Duration:  duration,
 		Tags:      t.Opts.Tags,
+		TaskID:    t.taskID(),
 		Completed: false,
 		StartTime: startTime,
 		EndTime:   endTime,
@@ -210,51 +293,42 @@ func (t *Timer) newSession(
 	}
 }
 
-// nextSession determines the type of the next session based on the current
-// session and work cycle count.
-func (t *Timer) nextSession(current config.SessType) config.SessType {
-	var next config.SessType
-
-	switch current {
-	case config.Work:
-		if t.WorkCycle == t.Opts.LongBreakInterval {
-			next = config.LongBreak
-		} else {
-			next = config.ShortBreak
-		}
-	case config.ShortBreak, config.LongBreak:
-		next = config.Work
-	}
-
-	return next
+// currentStep returns the step in the cycle of the current session.
+func (t *Timer) currentStep() config.Step {
+	return t.Opts.Cycle[t.step]
 }
 
-// initSession prepares the next session based on the current session type.
+// initSession prepares the session that follows the current one in the cycle.
 // It handles work cycle counting, session creation, and auto-start settings.
 // Returns a tea.Cmd for initializing the timer if auto-start is enabled.
 func (t *Timer) initSession() tea.Cmd {
-	sessName := t.nextSession(t.Current.Name)
-	t.Current = t.newSession(sessName)
+	t.step = (t.step + 1) % len(t.Opts.Cycle)
+	t.Current = t.newSession(t.currentStep())
+
+	t.abandoned = false
 
 	if t.Current.Name == config.Work && !t.Opts.AutoStartWork ||
 		t.Current.Name != config.Work && !t.Opts.AutoStartBreak {
 		t.waitForNextSession = true
 	}
 
-	// increment or reset the work cycle accordingly
-	if sessName == config.Work {
-		if t.WorkCycle == t.Opts.LongBreakInterval {
-			t.WorkCycle = 1
-		} else {
-			t.WorkCycle++
-		}
+	if t.Current.Name == config.Work {
+		t.WorkCycle = t.Opts.Cycle.WorkNumber(t.step)
 	}
 
 	if !t.waitForNextSession {
 		t.clock = btimer.New(t.Current.Duration)
-		return t.clock.Init()
+
+		return tea.Batch(
+			t.clock.Init(),
+			t.hook(t.startEvent()),
+			t.promptIntent(),
+		)
 	}
 
+	// the status shows which session the timer is waiting for
+	_ = t.writeStatusFile()
+
 	return nil
 }
 
@@ -262,15 +336,17 @@ func (t *Timer) initSession() tea.Cmd {
 // If --since is specified, adjusts the session time accordingly.
 // Returns the session and marks it completed if the end time is in the past.
 func (t *Timer) createSession() (*Session, error) {
-	sess := t.newSession(config.Work)
+	// the cycle always starts with a work session
+	sess := t.newSession(t.Opts.Cycle[0])
 
 	if t.Opts.Since != "" {
 		sess.Adjust(t.Opts.StartTime)
 
 		if time.Now().After(sess.EndTime) {
 			t.Current = sess
+			t.unsaved = append(t.unsaved, t.snapshot())
 
-			err := t.persist()
+			err := t.flush()
 			if err != nil {
 				return nil, err
 			}
@@ -282,221 +358,324 @@ func (t *Timer) createSession() (*Session, error) {
 	return sess, nil
 }
 
-func (t *Timer) postSession() error {
-	// t.notify(t.Context, t.Current.Name, sessName)
-	err := t.runSessionCmd(t.Opts.SessionCmd)
-	if err != nil {
-		return err
-	}
+// saveMsg reports the result of saving the oldest unsaved changes.
+type saveMsg struct {
+	err   error
+	gen   int
+	saved int
+}
 
-	return nil
+// persist queues the current timer and session to be saved to the database,
+// and returns the command that saves them. Changes that cannot be saved, for
+// example because another command is holding the database, are kept and
+// retried with the next save so that they are not lost.
+func (t *Timer) persist() tea.Cmd {
+	t.unsaved = append(t.unsaved, t.snapshot())
+
+	return t.save()
 }
 
-// persist saves the current timer and session to the database.
-func (t *Timer) persist() error {
+// snapshot returns a function that saves the current state of the session and
+// its timer in a batch.
+func (t *Timer) snapshot() func(b store.Batch) error {
 	sess := *t.Current
 
-	if sess.Name != config.Work {
-		return nil
-	}
-
 	sess.UpdateEndTime(t.clock.Timedout())
 
 	sess.Normalise()
 
 	sessModel := sess.ToDBModel()
+	strict := t.Opts.Strict
+	workCycle := t.WorkCycle
+
+	// the session and its timer are saved together so that they never
+	// disagree about whether the session can be resumed
+	return func(b store.Batch) error {
+		err := b.PutSession(sessModel)
+		if err != nil {
+			return err
+		}
 
-	m := map[time.Time]*models.Session{
-		sess.StartTime: sessModel,
+		// only work sessions can be resumed
+		if sessModel.Name != config.Work {
+			return nil
+		}
+
+		// completed sessions can no longer be resumed, and sessions
+		// interrupted in strict mode are abandoned
+		if sessModel.Completed || strict {
+			return b.DeleteTimer(sessModel.StartTime)
+		}
+
+		return b.PutTimer(&models.Timer{
+			SessionKey: sessModel.StartTime,
+			PausedTime: sessModel.EndTime,
+			WorkCycle:  workCycle,
+		})
 	}
+}
 
-	err := t.db.UpdateSessions(m)
-	if err != nil {
-		return err
+// save returns a command that saves the unsaved changes in the order that
+// they were made outside the event loop, since the database may be held by
+// another command for a while. Only one save runs at a time, and its result is
+// reported through a saveMsg.
+func (t *Timer) save() tea.Cmd {
+	if len(t.unsaved) == 0 || t.saving {
+		return nil
 	}
 
-	return nil
-}
+	t.saving = true
 
-// writeStatusFile writes the current timer status to a JSON file.
-// The status includes session details, work cycle count, and timing information.
-// This file is used by other processes to query the timer's current state.
-func (t *Timer) writeStatusFile() error {
-	sess := t.Current
+	changes := slices.Clone(t.unsaved)
+	db := t.db
+	gen := t.saveGen
 
-	s := report.Status{
-		Name:              string(sess.Name),
-		WorkCycle:         t.WorkCycle,
-		Tags:              sess.Tags,
-		LongBreakInterval: t.Opts.LongBreakInterval,
-		EndTime:           sess.EndTime,
-	}
+	return func() tea.Msg {
+		t.saveMu.Lock()
+		defer t.saveMu.Unlock()
 
-	statusFilePath := config.StatusFilePath()
+		// the changes were flushed before the save could start
+		if gen != t.saveGen {
+			return saveMsg{gen: gen}
+		}
 
-	statusFile, err := os.Create(statusFilePath)
-	if err != nil {
-		return err
+		return saveMsg{
+			gen:   gen,
+			saved: len(changes),
+			err:   applyChanges(db, changes),
+		}
 	}
+}
 
-	defer func() {
-		ferr := statusFile.Close()
-		if ferr != nil {
-			err = ferr
+// applyChanges saves the provided changes to the database in a single batch.
+func applyChanges(db store.DB, changes []func(b store.Batch) error) error {
+	return db.BatchUpdate(func(b store.Batch) error {
+		for _, fn := range changes {
+			err := fn(b)
+			if err != nil {
+				return err
+			}
 		}
-	}()
 
-	b, err := json.Marshal(s)
-	if err != nil {
-		return err
+		return nil
+	})
+}
+
+// saved handles the result of a save. The error is shown in the timer until
+// the changes are saved.
+func (t *Timer) saved(msg saveMsg) tea.Cmd {
+	// the changes of saves started before a flush are saved by the flush
+	if msg.gen != t.saveGen {
+		return nil
 	}
 
-	writer := bufio.NewWriter(statusFile)
+	t.saving = false
 
-	_, err = writer.Write(b)
-	if err != nil {
-		return err
+	if msg.err != nil {
+		t.saveErr = msg.err
+
+		slog.Error(
+			"unable to save the session",
+			slog.Int("pending_changes", len(t.unsaved)),
+			slog.Any("error", msg.err),
+		)
+
+		return nil
 	}
 
-	return writer.Flush()
+	t.unsaved = t.unsaved[msg.saved:]
+	t.saveErr = nil
+
+	// save the changes that were made in the meantime
+	return t.save()
 }
 
-// runSessionCmd executes the specified command (if any) after a session
-// completes.
-func (t *Timer) runSessionCmd(sessionCmd string) error {
-	if sessionCmd == "" {
+// flush saves the unsaved changes before returning, once the save in progress
+// (if any) has completed. It is used when the timer is about to exit, and
+// before it starts.
+func (t *Timer) flush() error {
+	t.saveMu.Lock()
+	t.saveGen++
+	t.saveMu.Unlock()
+
+	t.saving = false
+
+	if len(t.unsaved) == 0 {
 		return nil
 	}
 
-	cmdSlice, err := shellquote.Split(sessionCmd)
+	// the changes of the save that was in progress are applied again in
+	// case it failed, which is harmless since they are idempotent
+	err := applyChanges(t.db, t.unsaved)
 	if err != nil {
-		return fmt.Errorf("unable to parse session_cmd option: %w", err)
-	}
+		t.saveErr = err
 
-	if len(cmdSlice) == 0 {
-		return nil
-	}
+		slog.Error(
+			"unable to save the session",
+			slog.Int("pending_changes", len(t.unsaved)),
+			slog.Any("error", err),
+		)
 
-	name := cmdSlice[0]
-	args := cmdSlice[1:]
+		return err
+	}
 
-	cmd := exec.Command(name, args...)
+	t.unsaved = nil
+	t.saveErr = nil
 
-	return cmd.Run()
+	return nil
 }
 
-// notify sends a desktop notification and plays a notification sound when a
-// session ends if enabled.
-func (t *Timer) notify(
-	_ context.Context,
-	sessName, nextSessName config.SessType,
-) {
-	if !t.Opts.Notify {
-		return
-	}
+// flushBeforeExit retries saving the unsaved changes a few times since they
+// are lost once the timer exits.
+func (t *Timer) flushBeforeExit() {
+	for i := range exitSaveAttempts {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
 
-	title := string(sessName + " is finished")
+		if t.flush() == nil {
+			return
+		}
+	}
+}
 
-	msg := t.Opts.Message[nextSessName]
+// SaveErr returns the error that prevented the sessions from being saved
+// before the timer exited, if any.
+func (t *Timer) SaveErr() error {
+	return t.saveErr
+}
 
-	sound := t.Opts.BreakSound
+// abandonSession records the current work session as abandoned and prepares
+// a fresh work session that starts when the user is ready.
+func (t *Timer) abandonSession() tea.Cmd {
+	saveCmd := t.persist()
 
-	if sessName != config.Work {
-		sound = t.Opts.WorkSound
+	if t.Opts.DailyGoal.IsSet() {
+		t.addProgress()
 	}
 
-	configDir := filepath.Base(filepath.Dir(t.Opts.PathToConfig))
+	hookCmd := t.hook(config.HookAbandon)
 
-	// pathToIcon will be an empty string if file is not found
-	pathToIcon, _ := xdg.SearchDataFile(
-		filepath.Join(configDir, "static", "icon.png"),
-	)
+	t.lastWork = t.Current.StartTime
+	t.Current = t.newSession(t.currentStep())
+	t.clock = btimer.New(t.Current.Duration)
+	t.abandoned = true
+	t.waitForNextSession = true
 
-	err := beeep.Notify(title, msg, pathToIcon)
-	if err != nil {
-		pterm.Error.Printfln("unable to display notification: %v", err)
+	_ = t.writeStatusFile()
+
+	return tea.Batch(saveCmd, hookCmd)
+}
+
+// status describes the current state of the timer.
+func (t *Timer) status() report.Status {
+	sess := t.Current
+
+	s := report.Status{
+		Name:              sess.Label(),
+		Kind:              string(sess.Name),
+		WorkCycle:         t.WorkCycle,
+		Tags:              sess.Tags,
+		LongBreakInterval: t.Opts.Cycle.WorkSessions(),
+		StartTime:         sess.StartTime,
+		EndTime:           sess.EndTime,
+		Duration:          sess.Duration,
+		Remaining:         max(t.clock.Timeout, 0),
+		Paused:            t.paused(),
+		Intent:            sess.Intent,
+		State:             report.StateRunning,
 	}
 
-	if sound == "off" || sound == "" {
-		return
+	// the next session has not started yet, so it has no start or end time
+	if t.waitForNextSession {
+		s.State = report.StateWaiting
+		s.StartTime = time.Time{}
+		s.EndTime = time.Time{}
+		s.Remaining = sess.Duration
 	}
 
-	stream, err := prepSoundStream(sound)
-	if err != nil {
-		pterm.Error.Printfln("unable to play sound: %v", err)
-		return
+	if t.task != nil {
+		s.Task = t.task.Name
 	}
 
-	done := make(chan bool)
+	if s.Paused {
+		s.State = report.StatePaused
+		s.PausedAt = t.PausedTime
+	}
 
-	speaker.Play(beep.Seq(stream, beep.Callback(func() {
-		done <- true
-	})))
+	s.Refresh(time.Now())
 
-	<-done
+	return s
+}
 
-	stream.Close()
+// writeStatusFile writes the current timer status to a JSON file.
+// The status includes session details, work cycle count, and timing information.
+// This file is used by other processes to query the timer's current state, so
+// it is replaced atomically to avoid exposing a partially written status. It is
+// only removed when the timer exits.
+func (t *Timer) writeStatusFile() error {
+	b, err := json.Marshal(t.status())
+	if err != nil {
+		return err
+	}
 
-	speaker.Clear()
-	speaker.Close()
-}
+	statusFilePath := config.StatusFilePath()
 
-// ReportStatus reports the status of the currently running timer.
-func (t *Timer) ReportStatus() error {
-	dbFilePath := pathutil.DBFilePath()
-	statusFilePath := pathutil.StatusFilePath()
+	f, err := os.CreateTemp(filepath.Dir(statusFilePath), ".status-*.json")
+	if err != nil {
+		return err
+	}
 
-	var fileMode fs.FileMode = 0o600
+	_, err = f.Write(b)
 
-	_, err := bolt.Open(dbFilePath, fileMode, &bolt.Options{
-		Timeout: 100 * time.Millisecond,
-	})
-	// This means focus is not running, so no status to report
+	cerr := f.Close()
 	if err == nil {
-		return nil
+		err = cerr
 	}
 
-	if !errors.Is(err, bolterr.ErrTimeout) {
-		return err
+	if err == nil {
+		err = os.Rename(f.Name(), statusFilePath)
 	}
 
-	fileBytes, err := os.ReadFile(statusFilePath)
 	if err != nil {
-		// missing file should not return an error
-		return nil
+		_ = os.Remove(f.Name())
 	}
 
-	var s report.Status
+	return err
+}
 
-	err = json.Unmarshal(fileBytes, &s)
-	if err != nil {
-		return err
+// removeStatusFile removes the status file if it exists.
+func removeStatusFile() error {
+	err := os.Remove(config.StatusFilePath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
 	}
 
-	sess := &Session{
-		EndTime: s.EndTime,
+	return err
+}
+
+// ReadStatus returns the status of the active timer from the status file, or
+// nil if no timer is active.
+func ReadStatus() (*report.Status, error) {
+	running, err := store.Running(config.LockFilePath())
+	if err != nil || !running {
+		return nil, err
 	}
-	tr := sess.Remaining()
 
-	if tr.T < 0 {
-		return nil
+	fileBytes, err := os.ReadFile(config.StatusFilePath())
+	if err != nil {
+		// missing file should not return an error
+		return nil, nil
 	}
 
-	var text string
+	var s report.Status
 
-	switch config.SessType(s.Name) {
-	case config.Work:
-		text = fmt.Sprintf("[Work %d/%d]",
-			s.WorkCycle,
-			s.LongBreakInterval,
-		)
-	case config.ShortBreak:
-		text = "[Short break]"
-	case config.LongBreak:
-		text = "[Long break]"
+	err = json.Unmarshal(fileBytes, &s)
+	if err != nil {
+		return nil, err
 	}
 
-	pterm.Printfln("%s: %02d:%02d", text, tr.M, tr.S)
+	s.Refresh(time.Now())
 
-	return nil
+	return &s, nil
 }
@@ -0,0 +1,554 @@
+package timer
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	btimer "github.com/charmbracelet/bubbles/timer"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/report"
+	"github.com/ayoisaiah/focus/store"
+)
+
+type PersistTest struct {
+	Name      string
+	Session   config.SessType
+	Remaining time.Duration
+	Strict    bool
+	Completed bool
+	Resumable bool
+}
+
+var persistTestCases = []PersistTest{
+	{
+		Name:      "Interrupted work session can be resumed",
+		Session:   config.Work,
+		Remaining: 10 * time.Minute,
+		Resumable: true,
+	},
+	{
+		Name:      "Completed work session",
+		Session:   config.Work,
+		Completed: true,
+	},
+	{
+		Name:      "Interrupted work session in strict mode",
+		Session:   config.Work,
+		Remaining: 10 * time.Minute,
+		Strict:    true,
+	},
+	{
+		Name:      "Interrupted break session",
+		Session:   config.ShortBreak,
+		Remaining: 2 * time.Minute,
+	},
+}
+
+// persistNow saves the current session and hands the result to the timer, like
+// the event loop does.
+func persistNow(timer *Timer) error {
+	cmd := timer.persist()
+	if cmd == nil {
+		return nil
+	}
+
+	msg, _ := cmd().(saveMsg)
+
+	_ = timer.saved(msg)
+
+	return msg.err
+}
+
+func TestPersist(t *testing.T) {
+	for _, tc := range persistTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			db := store.NewMemory()
+
+			timer := &Timer{
+				db: db,
+				Opts: &config.TimerConfig{
+					Duration: config.Duration{
+						config.Work:       25 * time.Minute,
+						config.ShortBreak: 5 * time.Minute,
+					},
+					Tags:   []string{"writing"},
+					Strict: tc.Strict,
+				},
+				clock: btimer.New(tc.Remaining),
+			}
+
+			timer.Current = timer.newSession(timer.Opts.Step(tc.Session))
+
+			err := persistNow(timer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sess, err := db.GetSession(timer.Current.StartTime)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sess.Completed != tc.Completed {
+				t.Errorf("expected completed to be %t, but got: %t", tc.Completed, sess.Completed)
+			}
+
+			timers, err := db.RetrievePausedTimers()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resumable := len(timers) == 1; resumable != tc.Resumable {
+				t.Errorf("expected resumable to be %t, but got: %t", tc.Resumable, resumable)
+			}
+		})
+	}
+}
+
+// lockedDB fails to apply batches while locked, like a database that is held
+// by another command.
+type lockedDB struct {
+	store.DB
+	locked bool
+}
+
+func (db *lockedDB) BatchUpdate(fn func(b store.Batch) error) error {
+	if db.locked {
+		return errors.New("timeout")
+	}
+
+	return db.DB.BatchUpdate(fn)
+}
+
+func TestPersistRetry(t *testing.T) {
+	db := &lockedDB{DB: store.NewMemory(), locked: true}
+
+	timer := &Timer{
+		db: db,
+		Opts: &config.TimerConfig{
+			Duration: config.Duration{config.Work: 25 * time.Minute},
+		},
+		clock: btimer.New(10 * time.Minute),
+	}
+
+	timer.Current = timer.newSession(timer.Opts.Step(config.Work))
+
+	err := persistNow(timer)
+	if err == nil {
+		t.Fatal("expected the session not to be saved while the database is locked")
+	}
+
+	if timer.SaveErr() == nil {
+		t.Error("expected the error to be reported")
+	}
+
+	db.locked = false
+
+	// a save that starts after the timer has flushed its changes is skipped
+	stale := timer.save()
+
+	err = timer.flush()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg, _ := stale().(saveMsg); msg.saved != 0 {
+		t.Errorf("expected the stale save to be skipped, but it saved %d changes", msg.saved)
+	}
+
+	if timer.SaveErr() != nil {
+		t.Errorf("expected the error to be cleared, but got: %v", timer.SaveErr())
+	}
+
+	_, err = db.GetSession(timer.Current.StartTime)
+	if err != nil {
+		t.Errorf("expected the session to be saved on retry, but got: %v", err)
+	}
+
+	timers, err := db.RetrievePausedTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(timers) != 1 {
+		t.Errorf("expected the session to be resumable, but got %d timers", len(timers))
+	}
+}
+
+// useTempDataDir points the status and lock files to a temporary directory for
+// the duration of the test.
+func useTempDataDir(t *testing.T) {
+	t.Helper()
+
+	// registered before the environment is changed so that it runs after the
+	// environment is restored
+	t.Cleanup(func() {
+		xdg.Reload()
+		config.InitializePaths()
+	})
+
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	config.InitializePaths()
+
+	err := os.MkdirAll(filepath.Dir(config.StatusFilePath()), 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+type StatusFileTest struct {
+	Name      string
+	State     string
+	Remaining time.Duration
+	Elapsed   time.Duration
+	Pause     bool
+	Abandon   bool
+}
+
+var statusFileTestCases = []StatusFileTest{
+	{
+		Name:      "Running session",
+		State:     report.StateRunning,
+		Remaining: 25 * time.Minute,
+	},
+	{
+		Name:      "Paused session",
+		State:     report.StatePaused,
+		Remaining: 10 * time.Minute,
+		Elapsed:   15 * time.Minute,
+		Pause:     true,
+	},
+	{
+		Name:      "Waiting for the next session",
+		State:     report.StateWaiting,
+		Remaining: 25 * time.Minute,
+		Abandon:   true,
+	},
+}
+
+func TestStatusFile(t *testing.T) {
+	for _, tc := range statusFileTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			useTempDataDir(t)
+
+			unlock, err := store.LockInstance(config.LockFilePath())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer unlock()
+
+			opts := &config.TimerConfig{
+				Duration: config.Duration{
+					config.Work:       25 * time.Minute,
+					config.ShortBreak: 5 * time.Minute,
+				},
+				Tags: []string{"writing"},
+			}
+
+			opts.Cycle = config.Cycle{
+				opts.Step(config.Work),
+				opts.Step(config.ShortBreak),
+			}
+
+			timer := &Timer{
+				db:        store.NewMemory(),
+				Opts:      opts,
+				WorkCycle: 1,
+				clock:     btimer.New(tc.Remaining),
+			}
+
+			timer.Current = timer.newSession(timer.currentStep())
+
+			err = timer.writeStatusFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.Pause {
+				timer.Update(timer.clock.Stop()())
+			}
+
+			if tc.Abandon {
+				timer.abandonSession()
+			}
+
+			status, err := ReadStatus()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status == nil {
+				t.Fatal("expected a status, but got nil")
+			}
+
+			if status.State != tc.State {
+				t.Errorf("expected state to be %q, but got: %q", tc.State, status.State)
+			}
+
+			if status.Paused != tc.Pause {
+				t.Errorf("expected paused to be %t, but got: %t", tc.Pause, status.Paused)
+			}
+
+			if !status.PausedAt.Equal(timer.PausedTime) {
+				t.Errorf("expected paused at to be %v, but got: %v", timer.PausedTime, status.PausedAt)
+			}
+
+			if status.Remaining != tc.Remaining {
+				t.Errorf("expected remaining to be %v, but got: %v", tc.Remaining, status.Remaining)
+			}
+
+			if status.Elapsed != tc.Elapsed {
+				t.Errorf("expected elapsed to be %v, but got: %v", tc.Elapsed, status.Elapsed)
+			}
+
+			timer.quit()
+
+			_, err = os.Stat(config.StatusFilePath())
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("expected the status file to be removed on quit, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestStatusLabel(t *testing.T) {
+	opts := cycleTimerConfig()
+
+	timer := &Timer{
+		db:    store.NewMemory(),
+		Opts:  opts,
+		clock: btimer.New(3 * time.Minute),
+	}
+
+	timer.Current = timer.newSession(config.Step{
+		Name: "stretch",
+		SessionType: config.SessionType{
+			Kind:     config.ShortBreak,
+			Duration: 3 * time.Minute,
+		},
+	})
+
+	status := timer.status()
+
+	if status.Name != "stretch" {
+		t.Errorf("expected name to be %q, but got: %q", "stretch", status.Name)
+	}
+
+	if status.Kind != string(config.ShortBreak) {
+		t.Errorf("expected kind to be %q, but got: %q", config.ShortBreak, status.Kind)
+	}
+}
+
+// cycleStep describes a session that the timer moves on to.
+type cycleStep struct {
+	Type      string
+	Kind      config.SessType
+	Duration  time.Duration
+	WorkCycle int
+}
+
+type InitSessionTest struct {
+	Name     string
+	Cycle    func(opts *config.TimerConfig) config.Cycle
+	Expected []cycleStep
+}
+
+var initSessionTestCases = []InitSessionTest{
+	{
+		Name: "Default cycle",
+		Cycle: func(opts *config.TimerConfig) config.Cycle {
+			return config.Cycle{
+				opts.Step(config.Work),
+				opts.Step(config.ShortBreak),
+				opts.Step(config.Work),
+				opts.Step(config.LongBreak),
+			}
+		},
+		Expected: []cycleStep{
+			{"break", config.ShortBreak, 5 * time.Minute, 1},
+			{"work", config.Work, 25 * time.Minute, 2},
+			{"long", config.LongBreak, 15 * time.Minute, 2},
+			{"work", config.Work, 25 * time.Minute, 1},
+			{"break", config.ShortBreak, 5 * time.Minute, 1},
+		},
+	},
+	{
+		Name: "Custom profile",
+		Cycle: func(opts *config.TimerConfig) config.Cycle {
+			return config.Cycle{
+				opts.Step(config.Work),
+				{
+					Name: "stretch",
+					SessionType: config.SessionType{
+						Kind:     config.ShortBreak,
+						Duration: 3 * time.Minute,
+					},
+				},
+				{
+					Name: "deep",
+					SessionType: config.SessionType{
+						Kind:     config.Work,
+						Duration: 90 * time.Minute,
+					},
+				},
+				{
+					Name: "walk",
+					SessionType: config.SessionType{
+						Kind:     config.LongBreak,
+						Duration: 20 * time.Minute,
+					},
+				},
+			}
+		},
+		Expected: []cycleStep{
+			{"stretch", config.ShortBreak, 3 * time.Minute, 1},
+			{"deep", config.Work, 90 * time.Minute, 2},
+			{"walk", config.LongBreak, 20 * time.Minute, 2},
+			{"work", config.Work, 25 * time.Minute, 1},
+			{"stretch", config.ShortBreak, 3 * time.Minute, 1},
+		},
+	},
+}
+
+// cycleTimerConfig returns a timer configuration with the built-in session
+// durations that starts every session automatically.
+func cycleTimerConfig() *config.TimerConfig {
+	return &config.TimerConfig{
+		Duration: config.Duration{
+			config.Work:       25 * time.Minute,
+			config.ShortBreak: 5 * time.Minute,
+			config.LongBreak:  15 * time.Minute,
+		},
+		AutoStartWork:  true,
+		AutoStartBreak: true,
+	}
+}
+
+func TestInitSession(t *testing.T) {
+	for _, tc := range initSessionTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			opts := cycleTimerConfig()
+			opts.Cycle = tc.Cycle(opts)
+
+			timer := &Timer{
+				db:        store.NewMemory(),
+				Opts:      opts,
+				WorkCycle: 1,
+			}
+
+			timer.Current = timer.newSession(timer.currentStep())
+
+			for i, v := range tc.Expected {
+				_ = timer.initSession()
+
+				got := cycleStep{
+					timer.Current.Type,
+					timer.Current.Name,
+					timer.Current.Duration,
+					timer.WorkCycle,
+				}
+
+				if got != v {
+					t.Errorf("session %d: expected %+v, but got: %+v", i+1, v, got)
+				}
+			}
+		})
+	}
+}
+
+type ResumeTest struct {
+	Name string
+	// WorkCycle is the work cycle of the interrupted session
+	WorkCycle int
+	Reset     bool
+	Expected  cycleStep
+	// Next is the session after the resumed one
+	Next cycleStep
+}
+
+var resumeTestCases = []ResumeTest{
+	{
+		Name:      "Work cycle within the cycle",
+		WorkCycle: 2,
+		Expected:  cycleStep{"work", config.Work, 25 * time.Minute, 2},
+		Next:      cycleStep{"long", config.LongBreak, 15 * time.Minute, 2},
+	},
+	{
+		Name:      "Cycle with fewer work sessions than the work cycle",
+		WorkCycle: 4,
+		Expected:  cycleStep{"work", config.Work, 25 * time.Minute, 1},
+		Next:      cycleStep{"break", config.ShortBreak, 5 * time.Minute, 1},
+	},
+	{
+		Name:      "Reset the work cycle",
+		WorkCycle: 2,
+		Reset:     true,
+		Expected:  cycleStep{"work", config.Work, 25 * time.Minute, 1},
+		Next:      cycleStep{"break", config.ShortBreak, 5 * time.Minute, 1},
+	},
+}
+
+func TestResume(t *testing.T) {
+	for _, tc := range resumeTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			db := store.NewMemory()
+
+			opts := cycleTimerConfig()
+			opts.Cycle = config.Cycle{
+				opts.Step(config.Work),
+				opts.Step(config.ShortBreak),
+				opts.Step(config.Work),
+				opts.Step(config.LongBreak),
+			}
+
+			// a work session that was interrupted in the specified work cycle
+			interrupted := &Timer{
+				db:        db,
+				Opts:      opts,
+				WorkCycle: tc.WorkCycle,
+				clock:     btimer.New(10 * time.Minute),
+			}
+
+			interrupted.Current = interrupted.newSession(opts.Step(config.Work))
+
+			err := persistNow(interrupted)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			timer := &Timer{
+				db:   db,
+				Opts: opts,
+			}
+
+			err = timer.Resume(1, tc.Reset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range []cycleStep{tc.Expected, tc.Next} {
+				got := cycleStep{
+					timer.Current.Type,
+					timer.Current.Name,
+					timer.Current.Duration,
+					timer.WorkCycle,
+				}
+
+				if got != v {
+					t.Errorf("expected %+v, but got: %+v", v, got)
+				}
+
+				_ = timer.initSession()
+			}
+		})
+	}
+}
@@ -17,53 +17,109 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case btimer.TickMsg:
+		// ignore stray ticks from an abandoned session's clock
+		if t.waitForNextSession {
+			return t, nil
+		}
+
 		t.clock, cmd = t.clock.Update(msg)
 
 		_ = t.writeStatusFile()
 
 		// Persist timer every 60 seconds to aid recovery in case of unintended
-		// interruption
-		if int(t.clock.Timeout.Seconds())%60 == 0 {
-			go func() {
-				_ = t.persist()
-			}()
+		// interruption, and retry any changes that could not be saved more
+		// often
+		switch secs := int(t.clock.Timeout.Seconds()); {
+		case secs%60 == 0:
+			cmd = tea.Batch(cmd, t.persist())
+		case secs%saveRetryInterval == 0:
+			cmd = tea.Batch(cmd, t.save())
 		}
 
 		return t, cmd
 
+	case saveMsg:
+		return t, t.saved(msg)
+
 	case btimer.StartStopMsg:
 		t.clock, cmd = t.clock.Update(msg)
 
 		if t.clock.Running() {
 			t.StartTime = time.Now()
+			t.PausedTime = time.Time{}
 			t.Current.SetEndTime()
-		} else {
-			_ = t.persist()
+
+			_ = t.writeStatusFile()
+
+			return t, tea.Batch(cmd, t.hook(config.HookResume))
 		}
 
-		return t, cmd
+		t.PausedTime = time.Now()
+
+		saveCmd := t.persist()
+		_ = t.writeStatusFile()
+
+		return t, tea.Batch(cmd, saveCmd, t.hook(config.HookPause))
 
 	case btimer.TimeoutMsg:
-		_ = t.persist()
+		// the session is saved by finish if the timer exits, or along with
+		// the next session otherwise
+		t.unsaved = append(t.unsaved, t.snapshot())
+
+		hookCmd := t.hook(t.endEvent())
 
-		_ = t.postSession()
+		prevSessName := t.Current.Name
+
+		var reflectCmd tea.Cmd
+
+		if prevSessName == config.Work {
+			if t.recordWork() {
+				return t, t.finish(hookCmd)
+			}
+
+			t.lastWork = t.Current.StartTime
+			reflectCmd = t.promptReflection()
+		}
 
 		cmd = t.initSession()
 
-		return t, cmd
+		return t, tea.Batch(
+			t.save(),
+			cmd,
+			hookCmd,
+			t.notify(prevSessName, t.currentStep()),
+			reflectCmd,
+		)
 
 	case tea.KeyMsg:
+		if t.editingNote() {
+			return t, t.updateNote(msg)
+		}
+
+		if t.confirmAbandon {
+			switch {
+			case key.Matches(msg, defaultKeymap.confirm):
+				t.confirmAbandon = false
+
+				return t, t.abandonSession()
+
+			case key.Matches(msg, defaultKeymap.cancel):
+				t.confirmAbandon = false
+
+				return t, nil
+			}
+		}
+
 		switch {
 		case key.Matches(msg, defaultKeymap.enter):
-			if t.settings != "" {
+			if t.settings != "" || !t.waitForNextSession {
 				break
 			}
 
-			t.waitForNextSession = false
-			t.clock = btimer.New(t.Current.Duration)
-			cmd = t.clock.Init()
+			return t, t.startNextSession()
 
-			return t, cmd
+		case key.Matches(msg, defaultKeymap.note):
+			return t, t.toggleNote()
 
 		case key.Matches(msg, defaultKeymap.sound):
 			if !t.clock.Timedout() {
@@ -73,9 +129,10 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return t, nil
 
 		case key.Matches(msg, defaultKeymap.esc):
-			// Skip break sessions
-			if t.Current.Name != config.Work && t.clock.Running() {
-				return t, tea.Batch(t.clock.Stop(), t.initSession())
+			// Skip break sessions unless strict mode forbids it
+			if t.Current.Name != config.Work && t.clock.Running() &&
+				!t.Opts.Strict {
+				return t, t.skipSession()
 			}
 
 			t.settings = ""
@@ -87,18 +144,34 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return t, nil
 			}
 
-			// TODO: Check strict mode
+			// Pausing is not allowed in strict mode, so the user must confirm
+			// that the session should be abandoned instead
+			if t.Opts.Strict {
+				if t.clock.Running() {
+					t.confirmAbandon = true
+				}
+
+				return t, nil
+			}
 
 			cmd = t.clock.Toggle()
 
 			return t, cmd
 
 		case key.Matches(msg, defaultKeymap.quit):
-			_ = t.persist()
-
-			return t, tea.Batch(tea.ClearScreen, tea.Quit)
+			return t, t.quit()
 		}
 
+	case stopMsg:
+		return t, t.quit()
+
+	case controlMsg:
+		resp, cmd := t.control(msg.req)
+
+		msg.reply <- resp
+
+		return t, cmd
+
 	case tea.WindowSizeMsg:
 		t.progress.Width = msg.Width - padding*2 - 4
 		if t.progress.Width > maxWidth {
@@ -117,6 +190,10 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return t, cmd
 	}
 
+	if t.editingNote() {
+		return t, t.updateNote(msg)
+	}
+
 	form, cmd := t.soundForm.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		t.soundForm = f
@@ -125,3 +202,51 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return t, nil
 }
+
+// startNextSession starts the session that is waiting for the user to be
+// ready.
+func (t *Timer) startNextSession() tea.Cmd {
+	// the session starts when the user is ready, not when it was queued
+	t.Current = t.newSession(t.currentStep())
+	t.waitForNextSession = false
+	t.abandoned = false
+	t.clock = btimer.New(t.Current.Duration)
+
+	return tea.Batch(
+		t.clock.Init(),
+		t.hook(t.startEvent()),
+		t.promptIntent(),
+	)
+}
+
+// skipSession ends the current break session early and moves on to the next
+// session.
+func (t *Timer) skipSession() tea.Cmd {
+	t.Current.Skipped = true
+	saveCmd := t.persist()
+
+	hookCmd := t.hook(t.endEvent())
+
+	return tea.Batch(saveCmd, hookCmd, t.initSession())
+}
+
+// quit saves the current session and exits the timer. Work sessions that are
+// interrupted in strict mode are abandoned since they cannot be resumed.
+func (t *Timer) quit() tea.Cmd {
+	var hookCmd tea.Cmd
+
+	// a session that hasn't started yet has nothing to save
+	if !t.waitForNextSession {
+		t.unsaved = append(t.unsaved, t.snapshot())
+
+		if t.Opts.Strict && t.Current.Name == config.Work {
+			hookCmd = t.hook(config.HookAbandon)
+		}
+	}
+
+	t.flushBeforeExit()
+
+	_ = removeStatusFile()
+
+	return tea.Sequence(tea.Batch(tea.ClearScreen, hookCmd), tea.Quit)
+}
@@ -28,7 +28,11 @@ func (t *Timer) sessionPromptView() string {
 	title := "Your focus session is complete"
 	msg := "It's time to take a well-deserved break!"
 
-	if t.Current.Name == config.Work {
+	switch {
+	case t.abandoned:
+		title = "Your focus session was abandoned"
+		msg = "Start a new session when you're ready to focus again."
+	case t.Current.Name == config.Work:
 		title = "Your break is over"
 		msg = "Time to refocus and get back to work!"
 	}
@@ -41,6 +45,10 @@ func (t *Timer) sessionPromptView() string {
 	)
 	s.WriteString("\n\n" + msg)
 
+	if t.saveErr != nil {
+		s.WriteString("\n\n" + t.saveErrView())
+	}
+
 	return s.String()
 }
 
@@ -55,14 +63,15 @@ func (t *Timer) timerView() string {
 
 	timeRemaining := t.formatTimeRemaining()
 
-	switch t.Current.Name {
-	case config.Work:
-		s.WriteString(defaultStyle.work.Render())
-	case config.ShortBreak:
-		s.WriteString(defaultStyle.shortBreak.Render())
-	case config.LongBreak:
-		s.WriteString(defaultStyle.longBreak.Render())
-	}
+	step := t.currentStep()
+
+	s.WriteString(
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Color(step.Color)).
+			MarginRight(1).
+			SetString(step.Message).
+			Render(),
+	)
 
 	var timeFormat string
 	if t.Opts.TwentyFourHourClock {
@@ -85,6 +94,14 @@ func (t *Timer) timerView() string {
 		)
 	}
 
+	// the message of a user-defined session type may be inherited from the
+	// built-in session, so its name is shown as well
+	if label := t.Current.Label(); label != string(t.Current.Name) {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(" · " + label).String()))
+	}
+
 	if t.Current.Name == config.Work {
 		s.WriteString(
 			strings.TrimSpace(
@@ -92,21 +109,68 @@ func (t *Timer) timerView() string {
 					fmt.Sprintf(
 						" (%d/%d)",
 						t.WorkCycle,
-						t.Opts.LongBreakInterval,
+						t.Opts.Cycle.WorkSessions(),
 					),
 				).String()))
 	}
 
+	if t.task != nil {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(" · " + TaskLabel(t.task)).String()))
+	}
+
+	if estimates := t.estimatesView(); estimates != "" {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(" · " + estimates).String()))
+	}
+
+	if progress := t.progressView(); progress != "" {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(" · " + progress).String()))
+	}
+
+	if t.saveErr != nil {
+		s.WriteString("\n\n")
+		s.WriteString(t.saveErrView())
+	}
+
 	s.WriteString("\n\n")
 	s.WriteString(timeRemaining)
 	s.WriteString("\n\n")
 	s.WriteString(t.progress.ViewAs(float64(1 - percent)))
 	s.WriteString("\n")
+
+	if t.confirmAbandon {
+		s.WriteString("\n")
+		s.WriteString(t.abandonPromptView())
+	}
+
 	s.WriteString(t.helpView())
 
 	return s.String()
 }
 
+// saveErrView reports that the session could not be saved and will be
+// retried.
+func (t *Timer) saveErrView() string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#DB2763")).
+		SetString("Unable to save the session, retrying: " + t.saveErr.Error()).
+		String()
+}
+
+// abandonPromptView asks the user to confirm abandoning the current session
+// when a pause is attempted in strict mode.
+func (t *Timer) abandonPromptView() string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#DB2763")).
+		SetString("Strict mode is enabled, so this session cannot be paused. Abandon it?").
+		String()
+}
+
 func (t *Timer) pickSoundView() string {
 	if t.soundForm.State == huh.StateCompleted {
 		sound := t.soundForm.GetString("sound")
@@ -129,13 +193,48 @@ func (t *Timer) settingsView() string {
 		return t.pickSoundView()
 	}
 
+	if t.editingNote() {
+		return t.noteForm.View()
+	}
+
 	return ""
 }
 
 func (t *Timer) helpView() string {
+	if t.editingNote() {
+		return "\n" + t.help.ShortHelpView([]key.Binding{
+			defaultKeymap.save,
+			defaultKeymap.discard,
+		})
+	}
+
 	if t.waitForNextSession {
 		return "\n" + t.help.ShortHelpView([]key.Binding{
 			defaultKeymap.enter,
+			defaultKeymap.note,
+			defaultKeymap.quit,
+		})
+	}
+
+	if t.confirmAbandon {
+		return "\n" + t.help.ShortHelpView([]key.Binding{
+			defaultKeymap.confirm,
+			defaultKeymap.cancel,
+		})
+	}
+
+	// pausing and skipping breaks are not allowed in strict mode
+	if t.Opts.Strict {
+		if t.Current.Name == config.Work {
+			return "\n" + t.help.ShortHelpView([]key.Binding{
+				defaultKeymap.sound,
+				defaultKeymap.note,
+				defaultKeymap.strictQuit,
+			})
+		}
+
+		return "\n" + t.help.ShortHelpView([]key.Binding{
+			defaultKeymap.note,
 			defaultKeymap.quit,
 		})
 	}
@@ -144,20 +243,28 @@ func (t *Timer) helpView() string {
 		return "\n" + t.help.ShortHelpView([]key.Binding{
 			defaultKeymap.togglePlay,
 			defaultKeymap.sound,
+			defaultKeymap.note,
 			defaultKeymap.quit,
 		})
 	}
 
 	return "\n" + t.help.ShortHelpView([]key.Binding{
 		defaultKeymap.esc,
+		defaultKeymap.note,
 		defaultKeymap.quit,
 	})
 }
 
 func (t *Timer) View() string {
 	if t.waitForNextSession {
+		prompt := t.sessionPromptView()
+
+		if t.editingNote() {
+			prompt += "\n\n" + t.settingsView()
+		}
+
 		return defaultStyle.base.Render(
-			t.sessionPromptView(),
+			prompt,
 			"\n",
 			t.helpView(),
 		)